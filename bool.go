@@ -38,8 +38,7 @@ func (g *ConfigGroup) Bool(name string, value bool, usage string) *Flag {
 		Usage:   usage,
 		value:   &BoolValue{Bound: bound},
 	}
-	g.Flags[name] = flag
-	g.flagOrder = append(g.flagOrder, name)
+	g.addFlag(name, flag)
 	return flag
 }
 
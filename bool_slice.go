@@ -47,8 +47,7 @@ func (g *ConfigGroup) BoolSlices(name string, value []bool, usage string) *Flag
 		Usage:   usage,
 		value:   &BoolSlicesValue{Bound: bound},
 	}
-	g.Flags[name] = flag
-	g.flagOrder = append(g.flagOrder, name)
+	g.addFlag(name, flag)
 
 	return flag
 }
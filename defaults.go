@@ -2,74 +2,65 @@ package dynflags
 
 import (
 	"fmt"
-	"sort"
+	"io"
 	"strings"
-	"text/tabwriter"
 )
 
 // PrintDefaults prints all registered flags
 func (df *DynFlags) PrintDefaults() {
-	w := tabwriter.NewWriter(df.output, 0, 8, 2, ' ', 0)
-
-	// Print title if present
-	if df.title != "" {
-		fmt.Fprintln(df.output, df.title) // nolint:errcheck
-		fmt.Fprintln(df.output)           // nolint:errcheck
-	}
+	df.helpFormatter().FormatHelp(df.output, df.HelpModel()) // nolint:errcheck
+}
 
-	// Print description if present
-	if df.description != "" {
-		fmt.Fprintln(df.output, df.description) // nolint:errcheck
-		fmt.Fprintln(df.output)                 // nolint:errcheck
+// PrintGroupDefaults prints the section of a single group, formatted as in PrintDefaults.
+// Hidden groups are printed as well. It returns an error if the group is not defined.
+func (df *DynFlags) PrintGroupDefaults(groupName string) error {
+	name, _ := df.resolveGroup(groupName)
+	group, exists := df.configGroups[name]
+	if !exists {
+		return fmt.Errorf("unknown group '%s'", groupName)
 	}
+	df.printGroupDefaults(df.output, group)
+	return nil
+}
 
-	// Sort group names
-	if df.SortGroups {
-		sort.Strings(df.groupOrder)
+// PrintDefaults prints the section of the group to w, formatted as in DynFlags.PrintDefaults.
+// Keys of child groups and group-level flags include the path of their parent groups.
+// Groups that were not defined through a DynFlags instance are printed with the default settings.
+func (cg *ConfigGroup) PrintDefaults(w io.Writer) {
+	df := cg.df
+	if df == nil {
+		df = New(ContinueOnError)
 	}
+	df.printGroupDefaults(w, cg)
+}
 
-	// Iterate over groups in the order they were added
-	for _, groupName := range df.groupOrder {
-		group := df.configGroups[groupName]
-
-		// Print group usage or fallback to uppercase group name
-		if group.usage != "" {
-			fmt.Fprintln(w, group.usage) // nolint:errcheck
-		} else {
-			fmt.Fprintln(w, strings.ToUpper(groupName)) // nolint:errcheck
-		}
-
-		// Sort flag names
-		if df.SortFlags {
-			sort.Strings(group.flagOrder)
-		}
-
-		// Print flags for the group
-		if len(group.flagOrder) > 0 {
-			fmt.Fprintln(w, "  Flag\tUsage") // nolint:errcheck
-			for _, flagName := range group.flagOrder {
-				flag := group.Flags[flagName]
-				usage := flag.Usage
-				if flag.Default != nil && flag.Default != "" {
-					usage = fmt.Sprintf("%s (default: %v)", flag.Usage, flag.Default)
-				}
-				metavar := string(flag.Type)
-				if flag.metaVar != "" {
-					metavar = flag.metaVar
-				}
+// printGroupDefaults prints the section of a group to w.
+func (df *DynFlags) printGroupDefaults(w io.Writer, group *ConfigGroup) {
+	groupPath, path := df.helpPath(group)
 
-				fmt.Fprintf(w, "  --%s.<IDENTIFIER>.%s %s\t%s\n", groupName, flagName, metavar, usage) // nolint:errcheck
-			}
-			fmt.Fprintln(w, "") // nolint:errcheck
+	help := df.helpGroup(group, groupPath, path, df.VerboseHelp)
+	// Group-level flags are printed on their own
+	if group.isGlobal() {
+		help = HelpGroup{
+			Name:  groupPath,
+			Usage: group.parent.usage,
+			Flags: df.appendHelpFlags(nil, group, groupPath, true, path, false, df.VerboseHelp),
 		}
 	}
 
-	// tabwriter buffers output for alignment; flush now to ensure aligned flag output is printed before the epilog
-	w.Flush() // nolint:errcheck
+	pattern := df.keyPattern(append(path[:len(path):len(path)], "<flag>")...)
+	model := &HelpModel{
+		Pattern: df.argPrefix() + strings.ReplaceAll(pattern, "<IDENTIFIER>", "<identifier>"),
+		Width:   df.helpWidth(w),
+		Groups:  []HelpGroup{help},
+	}
+	df.helpFormatter().FormatHelp(w, model) // nolint:errcheck
+}
 
-	// Print epilog if present
-	if df.epilog != "" {
-		fmt.Fprintln(df.output)            // nolint:errcheck
-		fmt.Fprintln(df.output, df.epilog) // nolint:errcheck
+// helpFormatter returns the configured formatter, falling back to DefaultHelpFormatter.
+func (df *DynFlags) helpFormatter() HelpFormatter {
+	if df.formatter == nil {
+		return DefaultHelpFormatter{}
 	}
+	return df.formatter
 }
@@ -2,6 +2,7 @@ package dynflags_test
 
 import (
 	"bytes"
+	"strings"
 	"testing"
 
 	"github.com/containeroo/dynflags"
@@ -197,3 +198,339 @@ func TestPrintDefaults(t *testing.T) {
 		assert.NotContains(t, output, "Flag\tUsage")
 	})
 }
+
+func TestPrintDefaultsPrefixStyle(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Single dash prefix", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+		df := dynflags.New(dynflags.ContinueOnError)
+		df.SetOutput(&buf)
+		df.SetPrefixStyle(dynflags.PrefixSingleDash)
+		df.Group("test").String("flag", "", "Test flag")
+
+		df.Usage()
+
+		output := buf.String()
+		assert.Contains(t, output, "Usage: [-<group>.<identifier>.<flag> value]")
+		assert.Contains(t, output, "  -test.<IDENTIFIER>.flag STRING")
+	})
+
+	t.Run("No prefix", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+		df := dynflags.New(dynflags.ContinueOnError)
+		df.SetOutput(&buf)
+		df.SetPrefixStyle(dynflags.PrefixNone)
+		df.Group("test").String("flag", "", "Test flag")
+
+		df.PrintDefaults()
+
+		assert.Contains(t, buf.String(), "  test.<IDENTIFIER>.flag STRING")
+	})
+}
+
+func TestPrintDefaultsSeparator(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Custom separator", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+		df := dynflags.New(dynflags.ContinueOnError)
+		df.SetOutput(&buf)
+		df.SetSeparator(":")
+		df.Group("test").String("flag", "", "Test flag")
+
+		df.Usage()
+
+		output := buf.String()
+		assert.Contains(t, output, "Usage: [--<group>:<identifier>:<flag> value]")
+		assert.Contains(t, output, "  --test:<IDENTIFIER>:flag STRING")
+	})
+}
+
+func TestPrintDefaultsNestedGroups(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Child group flags render full path", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+		df := dynflags.New(dynflags.ContinueOnError)
+		df.SetOutput(&buf)
+		cluster := df.Group("cluster")
+		cluster.String("region", "", "Cluster region")
+		node := cluster.Group("node")
+		node.String("address", "", "Node address")
+		node.Group("disk").Int("size", 10, "Disk size")
+
+		df.PrintDefaults()
+
+		output := buf.String()
+		assert.Contains(t, output, "--cluster.<IDENTIFIER>.region STRING")
+		assert.Contains(t, output, "--cluster.<IDENTIFIER>.node.<IDENTIFIER>.address STRING")
+		assert.Contains(t, output, "--cluster.<IDENTIFIER>.node.<IDENTIFIER>.disk.<IDENTIFIER>.size INT")
+		assert.Contains(t, output, "(default: 10)")
+	})
+
+	t.Run("Group with only child group flags", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+		df := dynflags.New(dynflags.ContinueOnError)
+		df.SetOutput(&buf)
+		df.Group("cluster").Group("node").String("address", "", "Node address")
+
+		df.PrintDefaults()
+
+		output := buf.String()
+		assert.Contains(t, output, "CLUSTER")
+		assert.Contains(t, output, "Flag")
+		assert.Contains(t, output, "--cluster.<IDENTIFIER>.node.<IDENTIFIER>.address STRING")
+	})
+}
+
+func TestPrintDefaultsGlobalFlags(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Group-level flags are listed before identifier flags", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+		df := dynflags.New(dynflags.ContinueOnError)
+		df.SetOutput(&buf)
+		http := df.Group("http")
+		http.String("method", "GET", "HTTP method")
+		http.Global().Int("concurrency", 10, "Concurrent checks")
+
+		df.PrintDefaults()
+
+		output := buf.String()
+		global := strings.Index(output, "--http.concurrency INT")
+		identifier := strings.Index(output, "--http.<IDENTIFIER>.method STRING")
+		assert.NotEqual(t, -1, global)
+		assert.NotEqual(t, -1, identifier)
+		assert.Less(t, global, identifier)
+		assert.Contains(t, output, "Concurrent checks (default: 10)\n\n")
+	})
+
+	t.Run("Group-level flags of child groups", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+		df := dynflags.New(dynflags.ContinueOnError)
+		df.SetOutput(&buf)
+		df.Group("cluster").Group("node").Global().Int("retries", 0, "Retries per node")
+
+		df.PrintDefaults()
+
+		assert.Contains(t, buf.String(), "--cluster.<IDENTIFIER>.node.retries INT")
+	})
+}
+
+func TestPrintDefaultsHidden(t *testing.T) {
+	t.Parallel()
+
+	newDynFlags := func(buf *bytes.Buffer) *dynflags.DynFlags {
+		df := dynflags.New(dynflags.ContinueOnError)
+		df.SetOutput(buf)
+		http := df.Group("http")
+		http.String("method", "GET", "HTTP method")
+		http.Int("buffer-size", 4096, "Read buffer size").Hidden()
+		http.Global().Int("workers", 4, "Worker pool size").Hidden()
+		http.Group("internal").Hidden()
+		http.Sub("internal").String("trace", "", "Trace ID")
+		debug := df.Group("debug")
+		debug.Hidden()
+		debug.Bool("pprof", false, "Enable pprof")
+		return df
+	}
+
+	t.Run("Hidden flags and groups are not shown", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+		df := newDynFlags(&buf)
+
+		df.PrintDefaults()
+
+		output := buf.String()
+		assert.Contains(t, output, "--http.<IDENTIFIER>.method STRING")
+		assert.NotContains(t, output, "buffer-size")
+		assert.NotContains(t, output, "workers")
+		assert.NotContains(t, output, "internal")
+		assert.NotContains(t, output, "DEBUG")
+		assert.NotContains(t, output, "pprof")
+	})
+
+	t.Run("Verbose help shows hidden flags and groups", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+		df := newDynFlags(&buf)
+		df.VerboseHelp = true
+
+		df.PrintDefaults()
+
+		output := buf.String()
+		assert.Contains(t, output, "--http.<IDENTIFIER>.buffer-size INT")
+		assert.Contains(t, output, "Read buffer size (default: 4096) (hidden)")
+		assert.Contains(t, output, "--http.workers INT")
+		assert.Contains(t, output, "--http.<IDENTIFIER>.internal.<IDENTIFIER>.trace STRING")
+		assert.Contains(t, output, "DEBUG")
+		assert.Contains(t, output, "--debug.<IDENTIFIER>.pprof BOOL")
+	})
+
+	t.Run("Group with only hidden flags has no flag table", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+		df := dynflags.New(dynflags.ContinueOnError)
+		df.SetOutput(&buf)
+		df.Group("test").String("flag", "", "Test flag").Hidden()
+
+		df.PrintDefaults()
+
+		output := buf.String()
+		assert.Contains(t, output, "TEST")
+		assert.NotContains(t, output, "Flag")
+	})
+}
+
+func TestPrintDefaultsAliases(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Aliases are listed with the flag", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+		df := dynflags.New(dynflags.ContinueOnError)
+		df.SetOutput(&buf)
+		df.Group("http").String("timeout", "5s", "HTTP timeout").Alias("t")
+
+		df.PrintDefaults()
+
+		assert.Contains(t, buf.String(), "--http.<IDENTIFIER>.timeout, --http.<IDENTIFIER>.t STRING")
+	})
+}
+
+func TestPrintGroupDefaults(t *testing.T) {
+	t.Parallel()
+
+	newFlags := func() (*dynflags.DynFlags, *bytes.Buffer) {
+		var buf bytes.Buffer
+		df := dynflags.New(dynflags.ContinueOnError)
+		df.SetOutput(&buf)
+		df.Title("Title")
+		df.Epilog("Epilog")
+		http := df.Group("http")
+		http.Usage("HTTP flags")
+		http.String("method", "GET", "HTTP method")
+		df.Group("tcp").String("address", "", "TCP address")
+		return df, &buf
+	}
+
+	t.Run("Print a single group", func(t *testing.T) {
+		t.Parallel()
+
+		df, buf := newFlags()
+		err := df.PrintGroupDefaults("http")
+		assert.NoError(t, err)
+
+		expected := "HTTP flags\n" +
+			"  Flag                               Usage\n" +
+			"  --http.<IDENTIFIER>.method STRING  HTTP method (default: GET)\n" +
+			"\n"
+		assert.Equal(t, expected, buf.String())
+	})
+
+	t.Run("Print a group by its alias", func(t *testing.T) {
+		t.Parallel()
+
+		df, buf := newFlags()
+		df.GroupAlias("web", "http")
+		assert.NoError(t, df.PrintGroupDefaults("web"))
+		assert.Contains(t, buf.String(), "--http.<IDENTIFIER>.method")
+	})
+
+	t.Run("Unknown group", func(t *testing.T) {
+		t.Parallel()
+
+		df, buf := newFlags()
+		err := df.PrintGroupDefaults("udp")
+		assert.EqualError(t, err, "unknown group 'udp'")
+		assert.Empty(t, buf.String())
+	})
+
+	t.Run("ConfigGroup uses the settings of its instance", func(t *testing.T) {
+		t.Parallel()
+
+		df, _ := newFlags()
+		df.SetPrefixStyle(dynflags.PrefixSingleDash)
+		df.SetSeparator("/")
+
+		var buf bytes.Buffer
+		df.Config().Lookup("tcp").PrintDefaults(&buf)
+		assert.Contains(t, buf.String(), "TCP\n")
+		assert.Contains(t, buf.String(), "-tcp/<IDENTIFIER>/address STRING")
+		assert.NotContains(t, buf.String(), "Title")
+	})
+
+	t.Run("Child group keys include the parent path", func(t *testing.T) {
+		t.Parallel()
+
+		df := dynflags.New(dynflags.ContinueOnError)
+		df.SetSeparator(":")
+		node := df.Group("cluster").Group("node")
+		node.String("address", "", "Node address")
+
+		var buf bytes.Buffer
+		node.PrintDefaults(&buf)
+		assert.Contains(t, buf.String(), "CLUSTER:NODE\n")
+		assert.Contains(t, buf.String(), "  --cluster:<IDENTIFIER>:node:<IDENTIFIER>:address STRING  Node address\n")
+
+		// The printed key parses
+		assert.NoError(t, df.Parse([]string{"--cluster:eu:node:n1:address=10.0.0.1"}))
+	})
+
+	t.Run("Group-level flags are printed with the group path", func(t *testing.T) {
+		t.Parallel()
+
+		df := dynflags.New(dynflags.ContinueOnError)
+		cluster := df.Group("cluster")
+		cluster.String("region", "", "Region")
+		cluster.Group("node").Global().Int("replicas", 1, "Replicas per node")
+
+		var buf bytes.Buffer
+		cluster.Sub("node").Global().PrintDefaults(&buf)
+		assert.Contains(t, buf.String(), "  --cluster.<IDENTIFIER>.node.replicas INT  Replicas per node (default: 1)\n")
+		assert.NotContains(t, buf.String(), "region")
+	})
+
+	t.Run("Hidden group is printed on request", func(t *testing.T) {
+		t.Parallel()
+
+		df, buf := newFlags()
+		debug := df.Group("debug")
+		debug.Hidden()
+		debug.Bool("pprof", false, "Enable pprof")
+
+		assert.NoError(t, df.PrintGroupDefaults("debug"))
+		assert.Contains(t, buf.String(), "--debug.<IDENTIFIER>.pprof BOOL")
+	})
+
+	t.Run("ConfigGroup without instance uses the default settings", func(t *testing.T) {
+		t.Parallel()
+
+		group := &dynflags.ConfigGroup{Name: "udp", Flags: map[string]*dynflags.Flag{}}
+		group.String("address", "", "UDP address")
+
+		var buf bytes.Buffer
+		group.PrintDefaults(&buf)
+		assert.Contains(t, buf.String(), "--udp.<IDENTIFIER>.address STRING")
+	})
+}
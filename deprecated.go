@@ -0,0 +1,47 @@
+package dynflags
+
+import "fmt"
+
+// Deprecated marks the flag as deprecated. The flag still parses, but using it emits a warning
+// with the given message, and it is only shown in the help message if VerboseHelp is set.
+func (f *Flag) Deprecated(msg string) *Flag {
+	f.deprecated = msg
+	return f
+}
+
+// Alias keeps the old name of a renamed flag working. Values passed with the old name are stored
+// under the new name and emit a warning. The alias is only shown in the help message if VerboseHelp is set.
+// It panics if the new flag is not defined or the old name is still a flag or an alias of a flag.
+func (cg *ConfigGroup) Alias(oldName, newName string) {
+	if _, exists := cg.Flags[newName]; !exists {
+		panic(fmt.Sprintf("dynflags: alias '%s' refers to unknown flag '%s' in group '%s'", oldName, newName, cg.Name))
+	}
+	if _, exists := cg.Flags[oldName]; exists {
+		panic(fmt.Sprintf("dynflags: alias '%s' collides with a flag in group '%s'", oldName, cg.Name))
+	}
+	if flagName, flag := cg.lookupAlias(oldName); flag != nil {
+		panic(fmt.Sprintf("dynflags: alias '%s' collides with an alias of flag '%s' in group '%s'", oldName, flagName, cg.Name))
+	}
+	if cg.aliases == nil {
+		cg.aliases = make(map[string]string)
+	}
+	if _, exists := cg.aliases[oldName]; !exists {
+		cg.aliasOrder = append(cg.aliasOrder, oldName)
+	}
+	cg.aliases[oldName] = newName
+}
+
+// SetWarningFunc sets the function that receives warnings, e.g. about deprecated flags.
+// By default, warnings are written to the output.
+func (df *DynFlags) SetWarningFunc(warn func(msg string)) {
+	df.warn = warn
+}
+
+// warning emits a warning through the warning function or to the output.
+func (df *DynFlags) warning(msg string) {
+	if df.warn != nil {
+		df.warn(msg)
+		return
+	}
+	fmt.Fprintf(df.output, "warning: %s\n", msg) // nolint:errcheck
+}
@@ -0,0 +1,108 @@
+package dynflags_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/containeroo/dynflags"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeprecatedFlags(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Deprecated flag parses and warns", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+		df := dynflags.New(dynflags.ContinueOnError)
+		df.SetOutput(&buf)
+		df.Group("http").String("proxy", "", "Proxy URL").Deprecated("proxies are configured globally")
+
+		err := df.Parse([]string{"--http.a.proxy=http://proxy"})
+		assert.NoError(t, err)
+		assert.Equal(t, "http://proxy", df.Parsed().Lookup("http").Lookup("a").Lookup("proxy"))
+		assert.Equal(t, "warning: flag 'proxy' in group 'http' is deprecated: proxies are configured globally\n", buf.String())
+	})
+
+	t.Run("Alias parses into the new flag", func(t *testing.T) {
+		t.Parallel()
+
+		var warnings []string
+		df := dynflags.New(dynflags.ContinueOnError)
+		df.SetWarningFunc(func(msg string) { warnings = append(warnings, msg) })
+		http := df.Group("http")
+		http.Bool("insecure", false, "Skip TLS verification")
+		http.Alias("skip-tls-verify", "insecure")
+
+		err := df.Parse([]string{"--http.a.skip-tls-verify=true", "--http.b.insecure=true"})
+		assert.NoError(t, err)
+
+		parsed := df.Parsed().Lookup("http")
+		assert.Equal(t, true, parsed.Lookup("a").Lookup("insecure"))
+		assert.Nil(t, parsed.Lookup("a").Lookup("skip-tls-verify"))
+		assert.Equal(t, true, parsed.Lookup("b").Lookup("insecure"))
+		assert.Equal(t, []string{"flag 'skip-tls-verify' in group 'http' is deprecated, use 'insecure' instead"}, warnings)
+	})
+
+	t.Run("Alias to unknown flag panics", func(t *testing.T) {
+		t.Parallel()
+
+		group := &dynflags.ConfigGroup{Name: "http", Flags: make(map[string]*dynflags.Flag)}
+		assert.Panics(t, func() { group.Alias("skip-tls-verify", "insecure") })
+	})
+
+	t.Run("Alias shadowing a flag panics", func(t *testing.T) {
+		t.Parallel()
+
+		group := &dynflags.ConfigGroup{Name: "http", Flags: make(map[string]*dynflags.Flag)}
+		group.String("timeout", "1s", "Timeout").Alias("t")
+		group.String("request-timeout", "1s", "Request timeout")
+
+		assert.PanicsWithValue(t, "dynflags: alias 'timeout' collides with a flag in group 'http'", func() { group.Alias("timeout", "request-timeout") })
+		assert.PanicsWithValue(t, "dynflags: alias 't' collides with an alias of flag 'timeout' in group 'http'", func() { group.Alias("t", "request-timeout") })
+	})
+}
+
+func TestPrintDefaultsDeprecated(t *testing.T) {
+	t.Parallel()
+
+	newDynFlags := func(buf *bytes.Buffer) *dynflags.DynFlags {
+		df := dynflags.New(dynflags.ContinueOnError)
+		df.SetOutput(buf)
+		http := df.Group("http")
+		http.Bool("insecure", false, "Skip TLS verification")
+		http.Alias("skip-tls-verify", "insecure")
+		http.String("proxy", "", "Proxy URL").Deprecated("proxies are configured globally")
+		return df
+	}
+
+	t.Run("Deprecated flags and aliases are hidden", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+		df := newDynFlags(&buf)
+
+		df.PrintDefaults()
+
+		output := buf.String()
+		assert.Contains(t, output, "--http.<IDENTIFIER>.insecure BOOL")
+		assert.NotContains(t, output, "skip-tls-verify")
+		assert.NotContains(t, output, "proxy")
+	})
+
+	t.Run("Verbose help shows deprecated flags and aliases", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+		df := newDynFlags(&buf)
+		df.VerboseHelp = true
+
+		df.PrintDefaults()
+
+		output := buf.String()
+		assert.Contains(t, output, "--http.<IDENTIFIER>.skip-tls-verify BOOL")
+		assert.Contains(t, output, "Deprecated, use --http.<IDENTIFIER>.insecure instead")
+		assert.Contains(t, output, "Proxy URL (deprecated: proxies are configured globally)")
+	})
+}
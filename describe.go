@@ -0,0 +1,32 @@
+package dynflags
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// DescribeJSON returns the full configuration as JSON, e.g. for tools that build forms from the flags.
+// It serializes the help model of all groups and flags in the order they are printed,
+// including hidden and deprecated ones. Defaults and bounds are typed values as in a configuration
+// file: durations are written as strings like "1m30s" and slice defaults as arrays.
+func (df *DynFlags) DescribeJSON() ([]byte, error) {
+	model := df.helpModel(true)
+	for i := range model.Groups {
+		flags := model.Groups[i].Flags
+		for j := range flags {
+			flags[j].Default, _ = jsonValue(flags[j].Type, flags[j].Default)
+			flags[j].Min, _ = jsonValue(flags[j].Type, flags[j].Min)
+			flags[j].Max, _ = jsonValue(flags[j].Type, flags[j].Max)
+		}
+	}
+
+	// Keys like "--http.<IDENTIFIER>.method" stay readable without HTML escaping
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(model); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
@@ -0,0 +1,105 @@
+package dynflags_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/containeroo/dynflags"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDescribeJSON(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Describe groups and flags", func(t *testing.T) {
+		t.Parallel()
+
+		df := dynflags.New(dynflags.ContinueOnError)
+		df.Title("Checker")
+		http := df.Group("http")
+		http.Usage("HTTP flags")
+		http.Global().Int("concurrency", 0, "Concurrency")
+		http.Duration("timeout", 90*time.Second, "Timeout").Range(time.Second, 2*time.Minute)
+		http.String("verb", "GET", "Verb").Deprecated("use method").MetaVar("METHOD")
+		http.Bool("debug", false, "Debug").Hidden()
+		http.IntSlices("codes", []int{200, 204}, "Status codes").Range(100, 599)
+		debug := df.Group("debug")
+		debug.Hidden()
+
+		data, err := df.DescribeJSON()
+		assert.NoError(t, err)
+
+		expected := `{
+  "title": "Checker",
+  "pattern": "--<group>.<identifier>.<flag>",
+  "groups": [
+    {
+      "name": "http",
+      "usage": "HTTP flags",
+      "flags": [
+        {
+          "name": "concurrency",
+          "group": "http",
+          "global": true,
+          "key": "--http.concurrency",
+          "metaVar": "INT",
+          "type": "INT",
+          "default": 0,
+          "usage": "Concurrency"
+        },
+        {
+          "name": "timeout",
+          "group": "http",
+          "key": "--http.<IDENTIFIER>.timeout",
+          "metaVar": "DURATION",
+          "type": "DURATION",
+          "default": "1m30s",
+          "usage": "Timeout",
+          "min": "1s",
+          "max": "2m0s"
+        },
+        {
+          "name": "verb",
+          "group": "http",
+          "key": "--http.<IDENTIFIER>.verb",
+          "metaVar": "METHOD",
+          "type": "STRING",
+          "default": "GET",
+          "usage": "Verb",
+          "deprecated": "use method"
+        },
+        {
+          "name": "debug",
+          "group": "http",
+          "key": "--http.<IDENTIFIER>.debug",
+          "metaVar": "BOOL",
+          "type": "BOOL",
+          "default": false,
+          "usage": "Debug",
+          "hidden": true
+        },
+        {
+          "name": "codes",
+          "group": "http",
+          "key": "--http.<IDENTIFIER>.codes",
+          "metaVar": "..INTs",
+          "type": "..INTs",
+          "default": [
+            200,
+            204
+          ],
+          "usage": "Status codes",
+          "min": 100,
+          "max": 599
+        }
+      ]
+    },
+    {
+      "name": "debug",
+      "hidden": true
+    }
+  ]
+}`
+		assert.Equal(t, expected, string(data))
+	})
+}
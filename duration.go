@@ -38,8 +38,7 @@ func (g *ConfigGroup) Duration(name string, value time.Duration, usage string) *
 		Usage:   usage,
 		value:   &DurationValue{Bound: bound},
 	}
-	g.Flags[name] = flag
-	g.flagOrder = append(g.flagOrder, name)
+	g.addFlag(name, flag)
 	return flag
 }
 
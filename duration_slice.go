@@ -48,8 +48,7 @@ func (g *ConfigGroup) DurationSlices(name string, value []time.Duration, usage s
 		Usage:   usage,
 		value:   &DurationSlicesValue{Bound: bound},
 	}
-	g.Flags[name] = flag
-	g.flagOrder = append(g.flagOrder, name)
+	g.addFlag(name, flag)
 	return flag
 }
 
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"strings"
 )
 
 // ParseBehavior defines how Parse should behave on errors.
@@ -11,23 +12,53 @@ type ParseBehavior int
 
 const (
 	ContinueOnError ParseBehavior = iota // Continue parsing on error
-	ExitOnError                          // Exit on error
+	ExitOnError                          // Print the error and usage, then exit with status 2
+	PanicOnError                         // Panic with the error
 )
 
+// PrefixStyle defines which argument prefix Parse expects in front of a key.
+type PrefixStyle int
+
+const (
+	PrefixDoubleDash PrefixStyle = iota // --group.identifier.flag
+	PrefixSingleDash                    // -group.identifier.flag
+	PrefixAnyDash                       // --group.identifier.flag or -group.identifier.flag
+	PrefixNone                          // group.identifier.flag=value
+)
+
+// groupAlias is an alternative name of a group.
+type groupAlias struct {
+	canonical  string // Name of the group
+	deprecated bool   // Warn when the alias is used
+}
+
 // DynFlags manages configuration and parsed values
 type DynFlags struct {
-	configGroups  map[string]*ConfigGroup // Static parent groups
-	groupOrder    []string                // Order of group names
-	SortGroups    bool                    // Sort groups in help message
-	SortFlags     bool                    // Sort flags in help message
-	parsedGroups  GroupsMap               // Parsed child groups organized by parent group
-	parseBehavior ParseBehavior           // Parsing behavior
-	unparsedArgs  []string                // Arguments that couldn't be parsed
-	output        io.Writer               // Output for usage/help
-	usage         func()                  // Customizable usage function
-	title         string                  // Title in the help message
-	description   string                  // Description after the title in the help message
-	epilog        string                  // Epilog in the help message
+	configGroups   map[string]*ConfigGroup     // Static parent groups
+	groupOrder     []string                    // Order of group names
+	SortGroups     bool                        // Sort groups in help message
+	SortFlags      bool                        // Sort flags in help message
+	VerboseHelp    bool                        // Show hidden and deprecated flags and aliases in help message
+	parsedGroups   GroupsMap                   // Parsed child groups organized by parent group
+	parsedGlobals  map[string]*ParsedGroup     // Parsed group-level flags organized by parent group
+	parseBehavior  ParseBehavior               // Parsing behavior
+	prefixStyle    PrefixStyle                 // Accepted argument prefix
+	separator      string                      // Separator between the parts of a key
+	wildcard       string                      // Identifier whose values are inherited by all identifiers
+	requiredGroups []string                    // Groups that must be passed
+	validators     []func(*ParsedGroups) error // Validators for the whole configuration
+	groupAliases   map[string]groupAlias       // Alternative group names
+	unparsedArgs   []string                    // Arguments that couldn't be parsed
+	output         io.Writer                   // Output for usage/help
+	usage          func()                      // Customizable usage function
+	helpFlags      []string                    // Names of the flags requesting help
+	formatter      HelpFormatter               // Renders the help message; nil uses DefaultHelpFormatter
+	width          int                         // Line width of the help message; zero detects the terminal width
+	exit           func(code int)              // Called on errors with ExitOnError
+	warn           func(msg string)            // Receives warnings; nil writes them to output
+	title          string                      // Title in the help message
+	description    string                      // Description after the title in the help message
+	epilog         string                      // Epilog in the help message
 }
 
 // New initializes a new DynFlags instance
@@ -35,8 +66,13 @@ func New(behavior ParseBehavior) *DynFlags {
 	df := &DynFlags{
 		configGroups:  make(map[string]*ConfigGroup),
 		parsedGroups:  make(GroupsMap),
+		parsedGlobals: make(map[string]*ParsedGroup),
 		parseBehavior: behavior,
 		output:        os.Stdout,
+		separator:     ".",
+		wildcard:      "*",
+		exit:          os.Exit,
+		helpFlags:     []string{"help", "h"},
 	}
 	df.usage = func() { df.Usage() }
 	return df
@@ -57,21 +93,63 @@ func (df *DynFlags) Epilog(epilog string) {
 	df.epilog = epilog
 }
 
-// Group defines a new group or retrieves an existing one
+// Group defines a new group or retrieves an existing one.
+// It panics if the name is already a group alias.
 func (df *DynFlags) Group(name string) *ConfigGroup {
 	if _, exists := df.configGroups[name]; exists {
 		return df.configGroups[name]
 	}
+	if alias, exists := df.groupAliases[name]; exists {
+		panic(fmt.Sprintf("dynflags: group '%s' collides with an alias of group '%s'", name, alias.canonical))
+	}
 
 	df.groupOrder = append(df.groupOrder, name)
 	group := &ConfigGroup{
 		Name:  name,
 		Flags: make(map[string]*Flag),
+		df:    df,
 	}
 	df.configGroups[name] = group
 	return group
 }
 
+// GroupAlias makes the group also available under the alias, e.g. during a migration to a new name.
+// Flags passed with the alias are parsed into the canonical group.
+// It panics if the canonical group is not defined or the alias is the name of a defined group.
+func (df *DynFlags) GroupAlias(alias, canonical string) {
+	df.addGroupAlias(alias, canonical, false)
+}
+
+// DeprecatedGroupAlias works like GroupAlias, but emits a warning whenever the alias is used.
+func (df *DynFlags) DeprecatedGroupAlias(alias, canonical string) {
+	df.addGroupAlias(alias, canonical, true)
+}
+
+func (df *DynFlags) addGroupAlias(alias, canonical string, deprecated bool) {
+	if _, exists := df.configGroups[canonical]; !exists {
+		panic(fmt.Sprintf("dynflags: group alias '%s' refers to unknown group '%s'", alias, canonical))
+	}
+	if _, exists := df.configGroups[alias]; exists {
+		panic(fmt.Sprintf("dynflags: group alias '%s' collides with a defined group", alias))
+	}
+	if df.groupAliases == nil {
+		df.groupAliases = make(map[string]groupAlias)
+	}
+	df.groupAliases[alias] = groupAlias{canonical: canonical, deprecated: deprecated}
+}
+
+// resolveGroup returns the canonical name of a group and a warning if a deprecated alias was used.
+func (df *DynFlags) resolveGroup(name string) (canonical, warning string) {
+	alias, ok := df.groupAliases[name]
+	if !ok {
+		return name, ""
+	}
+	if alias.deprecated {
+		warning = fmt.Sprintf("group '%s' is deprecated, use '%s' instead", name, alias.canonical)
+	}
+	return alias.canonical, warning
+}
+
 // UnknownArgs returns the list of unparseable arguments.
 func (df *DynFlags) UnknownArgs() []string {
 	return df.unparsedArgs
@@ -79,7 +157,7 @@ func (df *DynFlags) UnknownArgs() []string {
 
 // DefaultUsage provides the default usage output
 func (df *DynFlags) Usage() {
-	fmt.Fprintf(df.output, "Usage: [--<group>.<identifier>.<flag> value]\n\n")
+	fmt.Fprintf(df.output, "Usage: [%s%s value]\n\n", df.argPrefix(), df.keyPattern("<group>", "<identifier>", "<flag>"))
 	df.PrintDefaults()
 }
 
@@ -87,3 +165,48 @@ func (df *DynFlags) Usage() {
 func (df *DynFlags) SetOutput(buf io.Writer) {
 	df.output = buf
 }
+
+// SetExitFunc sets the function called with the exit status on errors with ExitOnError (default os.Exit).
+// If it returns, Parse returns the error.
+func (df *DynFlags) SetExitFunc(exit func(code int)) {
+	df.exit = exit
+}
+
+// SetPrefixStyle sets the argument prefix accepted by Parse.
+func (df *DynFlags) SetPrefixStyle(style PrefixStyle) {
+	df.prefixStyle = style
+}
+
+// SetSeparator sets the separator between group, identifier and flag (default ".").
+// Identifiers containing the separator can be quoted with brackets
+// ("--http.[api.example.com].timeout") or escaped with a backslash.
+func (df *DynFlags) SetSeparator(sep string) {
+	if sep == "" {
+		panic("dynflags: separator must not be empty")
+	}
+	df.separator = sep
+}
+
+// SetWildcard sets the identifier whose values are inherited by all other identifiers
+// of the group that do not set them (default "*", e.g. "--http.*.timeout=10s").
+// An empty identifier disables the wildcard.
+func (df *DynFlags) SetWildcard(identifier string) {
+	df.wildcard = identifier
+}
+
+// keyPattern joins the given parts with the configured separator.
+func (df *DynFlags) keyPattern(parts ...string) string {
+	return strings.Join(parts, df.separator)
+}
+
+// argPrefix returns the prefix used to render flags in the help message.
+func (df *DynFlags) argPrefix() string {
+	switch df.prefixStyle {
+	case PrefixSingleDash:
+		return "-"
+	case PrefixNone:
+		return ""
+	default:
+		return "--"
+	}
+}
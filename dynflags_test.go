@@ -130,3 +130,92 @@ func TestDynFlagsUnknownArgs(t *testing.T) {
 		assert.Contains(t, unparsedArgs, "--unparsable")
 	})
 }
+
+func TestDynFlagsGroupAlias(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Alias parses into the canonical group", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+		df := dynflags.New(dynflags.ContinueOnError)
+		df.SetOutput(&buf)
+		socket := df.Group("socket")
+		socket.String("address", "", "Target address")
+		socket.String("name", "", "Checker name")
+		df.GroupAlias("tcp", "socket")
+
+		args := []string{
+			"--tcp.a.address=example.com:443",
+			"--socket.a.name=web",
+			"--tcp.b.address=example.com:80",
+		}
+		err := df.Parse(args)
+		assert.NoError(t, err)
+		assert.Empty(t, buf.String())
+
+		assert.Nil(t, df.Parsed().Lookup("tcp"))
+		assert.Len(t, df.Parsed().Groups(), 1)
+
+		parsed := df.Parsed().Lookup("socket")
+		assert.Equal(t, "example.com:443", parsed.Lookup("a").Lookup("address"))
+		assert.Equal(t, "web", parsed.Lookup("a").Lookup("name"))
+		assert.Equal(t, socket, parsed.Lookup("b").Parent)
+	})
+
+	t.Run("Deprecated alias warns", func(t *testing.T) {
+		t.Parallel()
+
+		var warnings []string
+		df := dynflags.New(dynflags.ContinueOnError)
+		df.SetWarningFunc(func(msg string) { warnings = append(warnings, msg) })
+		df.Group("socket").String("address", "", "Target address")
+		df.DeprecatedGroupAlias("tcp", "socket")
+
+		err := df.Parse([]string{"--tcp.a.address=example.com:443", "--socket.b.address=example.com:80"})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"group 'tcp' is deprecated, use 'socket' instead"}, warnings)
+	})
+
+	t.Run("Alias to unknown group panics", func(t *testing.T) {
+		t.Parallel()
+
+		df := dynflags.New(dynflags.ContinueOnError)
+		assert.Panics(t, func() { df.GroupAlias("tcp", "socket") })
+	})
+
+	t.Run("Alias colliding with a group panics", func(t *testing.T) {
+		t.Parallel()
+
+		df := dynflags.New(dynflags.ContinueOnError)
+		df.Group("socket")
+		df.Group("tcp")
+		assert.PanicsWithValue(t, "dynflags: group alias 'tcp' collides with a defined group", func() { df.GroupAlias("tcp", "socket") })
+
+		df.GroupAlias("unix", "socket")
+		assert.PanicsWithValue(t, "dynflags: group 'unix' collides with an alias of group 'socket'", func() { df.Group("unix") })
+	})
+
+	t.Run("References and required groups resolve aliases", func(t *testing.T) {
+		t.Parallel()
+
+		df := dynflags.New(dynflags.ContinueOnError)
+		df.Group("socket").String("address", "", "Target address")
+		df.GroupAlias("tcp", "socket")
+		df.Group("check").Ref("backend", "tcp", "Backend socket")
+		df.RequireGroup("tcp")
+
+		err := df.Parse([]string{"--tcp.db.address=db:5432", "--check.a.backend=db"})
+		assert.NoError(t, err)
+
+		target, err := df.Parsed().Lookup("check").Lookup("a").GetRef("backend")
+		assert.NoError(t, err)
+		assert.Equal(t, "db:5432", target.Lookup("address"))
+
+		df = dynflags.New(dynflags.ContinueOnError)
+		df.Group("socket").String("address", "", "Target address")
+		df.GroupAlias("tcp", "socket")
+		df.RequireGroup("tcp")
+		assert.EqualError(t, df.Parse(nil), "group 'tcp' is required")
+	})
+}
@@ -0,0 +1,62 @@
+package dynflags
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FlagError describes an invalid value passed for a known flag.
+type FlagError struct {
+	Group      string // Group of the flag; child groups are joined with the identifiers of their parents, e.g. "cluster.eu.node".
+	Identifier string // Identifier of the flag; empty for group-level flags.
+	Flag       string // Name of the flag.
+	Value      string // Value as passed on the command line.
+	Err        error  // Error returned by parsing, a constraint or a validator.
+}
+
+func (e *FlagError) Error() string {
+	if e.Identifier == "" {
+		return fmt.Sprintf("invalid value for flag '%s' in group '%s': %v", e.Flag, e.Group, e.Err)
+	}
+	return fmt.Sprintf("invalid value for flag '%s' in group '%s' (identifier '%s'): %v", e.Flag, e.Group, e.Identifier, e.Err)
+}
+
+func (e *FlagError) Unwrap() error {
+	return e.Err
+}
+
+// ValidationError aggregates the errors found while validating the parsed groups after parsing.
+type ValidationError struct {
+	Errors []error // Errors in the order they were found.
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+func (e *ValidationError) Unwrap() []error {
+	return e.Errors
+}
+
+// UnknownFlagError describes a flag that is not defined, with a suggestion if a similar name exists.
+type UnknownFlagError struct {
+	Group           string // Group as passed; child groups are joined with the identifiers of their parents, e.g. "cluster.eu.node".
+	Flag            string // Flag as passed.
+	Suggestion      string // Similar flag of the group, if any.
+	GroupSuggestion string // Similar group, if the group is not defined.
+}
+
+func (e *UnknownFlagError) Error() string {
+	msg := fmt.Sprintf("unknown flag '%s' in group '%s'", e.Flag, e.Group)
+	switch {
+	case e.Suggestion != "":
+		msg += fmt.Sprintf("; did you mean '%s'?", e.Suggestion)
+	case e.GroupSuggestion != "":
+		msg += fmt.Sprintf("; did you mean group '%s'?", e.GroupSuggestion)
+	}
+	return msg
+}
@@ -0,0 +1,74 @@
+package dynflags_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/containeroo/dynflags"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFlagError(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Error with identifier", func(t *testing.T) {
+		t.Parallel()
+
+		err := &dynflags.FlagError{Group: "http", Identifier: "a", Flag: "timeout", Value: "x", Err: errors.New("boom")}
+		assert.EqualError(t, err, "invalid value for flag 'timeout' in group 'http' (identifier 'a'): boom")
+	})
+
+	t.Run("Error of group-level flag", func(t *testing.T) {
+		t.Parallel()
+
+		err := &dynflags.FlagError{Group: "http", Flag: "concurrency", Value: "x", Err: errors.New("boom")}
+		assert.EqualError(t, err, "invalid value for flag 'concurrency' in group 'http': boom")
+	})
+
+	t.Run("Unwrap returns the underlying error", func(t *testing.T) {
+		t.Parallel()
+
+		cause := errors.New("boom")
+		err := &dynflags.FlagError{Group: "http", Flag: "concurrency", Err: cause}
+		assert.ErrorIs(t, err, cause)
+	})
+}
+
+func TestValidationError(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Errors are joined", func(t *testing.T) {
+		t.Parallel()
+
+		first, second := errors.New("first"), errors.New("second")
+		err := &dynflags.ValidationError{Errors: []error{first, second}}
+		assert.EqualError(t, err, "first; second")
+		assert.ErrorIs(t, err, first)
+		assert.ErrorIs(t, err, second)
+	})
+}
+
+func TestUnknownFlagError(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Error without suggestion", func(t *testing.T) {
+		t.Parallel()
+
+		err := &dynflags.UnknownFlagError{Group: "http", Flag: "timout"}
+		assert.EqualError(t, err, "unknown flag 'timout' in group 'http'")
+	})
+
+	t.Run("Error with flag suggestion", func(t *testing.T) {
+		t.Parallel()
+
+		err := &dynflags.UnknownFlagError{Group: "http", Flag: "timout", Suggestion: "timeout"}
+		assert.EqualError(t, err, "unknown flag 'timout' in group 'http'; did you mean 'timeout'?")
+	})
+
+	t.Run("Error with group suggestion", func(t *testing.T) {
+		t.Parallel()
+
+		err := &dynflags.UnknownFlagError{Group: "htp", Flag: "timeout", GroupSuggestion: "http"}
+		assert.EqualError(t, err, "unknown flag 'timeout' in group 'htp'; did you mean group 'http'?")
+	})
+}
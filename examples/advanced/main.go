@@ -1,7 +1,6 @@
 package main
 
 import (
-	"bytes"
 	"errors"
 	"fmt"
 	"io"
@@ -15,7 +14,7 @@ import (
 // Example version string
 var version = "v1.2.3"
 
-// HelpRequested is a custom error to indicate the user requested help or version info.
+// HelpRequested is a custom error to indicate the user requested version info or the flag description.
 type HelpRequested struct {
 	Message string
 }
@@ -32,7 +31,7 @@ func (e *HelpRequested) Is(target error) bool {
 
 // parseFlags orchestrates parsing both global flags (with pflag)
 // and dynamic flags (with dynflags). It returns any error that
-// indicates parsing failed, or HelpRequested for help/version output.
+// indicates parsing failed, dynflags.ErrHelp for help or HelpRequested for version or flag description output.
 func parseFlags(args []string, version string, output io.Writer) (*flag.FlagSet, *dynflags.DynFlags, error) {
 	// 1. Setup the global pflag FlagSet
 	flagSet := setupGlobalFlags()
@@ -43,11 +42,16 @@ func parseFlags(args []string, version string, output io.Writer) (*flag.FlagSet,
 	dynFlags.SetOutput(output)
 	dynFlags.SortFlags = true
 
-	// 3. Provide custom usage that prints both global and dynamic flags
+	// 3. Provide custom usage that prints both global and dynamic flags.
+	//    dynflags prints it on --help/-h and returns dynflags.ErrHelp.
 	setupUsage(flagSet, dynFlags)
+	dynFlags.SetUsage(flagSet.Usage)
 
 	// 4. Parse the dynamic flags first so we can separate known vs. unknown arguments
 	if err := dynFlags.Parse(args); err != nil {
+		if errors.Is(err, dynflags.ErrHelp) {
+			return nil, nil, err
+		}
 		return nil, nil, fmt.Errorf("error parsing dynamic flags: %w", err)
 	}
 
@@ -59,8 +63,8 @@ func parseFlags(args []string, version string, output io.Writer) (*flag.FlagSet,
 		return nil, nil, fmt.Errorf("error parsing global flags: %w", err)
 	}
 
-	// 6. Handle special flags for help and version
-	if err := handleSpecialFlags(flagSet, version); err != nil {
+	// 6. Handle the version and dump-flags flags
+	if err := handleSpecialFlags(flagSet, dynFlags, version); err != nil {
 		return nil, nil, err
 	}
 
@@ -74,7 +78,7 @@ func setupGlobalFlags() *flag.FlagSet {
 
 	// Some generic global flags:
 	flagSet.Bool("version", false, "Show version and exit.")
-	flagSet.BoolP("help", "h", false, "Show help.")
+	flagSet.Bool("dump-flags", false, "Print the dynamic flags as JSON and exit.")
 	flagSet.Duration("default-interval", 2*time.Second, "Default interval between checks.")
 	return flagSet
 }
@@ -130,22 +134,22 @@ func setupUsage(flagSet *flag.FlagSet, dynFlags *dynflags.DynFlags) {
 	}
 }
 
-// handleSpecialFlags checks if --help or --version was requested.
-func handleSpecialFlags(flagSet *flag.FlagSet, versionStr string) error {
-	helpFlag := flagSet.Lookup("help")
-	if helpFlag != nil && helpFlag.Value.String() == "true" {
-		// Capture usage output into a buffer, then return a HelpRequested error.
-		buffer := &bytes.Buffer{}
-		flagSet.SetOutput(buffer)
-		flagSet.Usage()
-		return &HelpRequested{Message: buffer.String()}
-	}
-
+// handleSpecialFlags checks if --version or --dump-flags was requested.
+func handleSpecialFlags(flagSet *flag.FlagSet, dynFlags *dynflags.DynFlags, versionStr string) error {
 	versionFlag := flagSet.Lookup("version")
 	if versionFlag != nil && versionFlag.Value.String() == "true" {
 		return &HelpRequested{Message: fmt.Sprintf("%s version %s\n", flagSet.Name(), versionStr)}
 	}
 
+	dumpFlag := flagSet.Lookup("dump-flags")
+	if dumpFlag != nil && dumpFlag.Value.String() == "true" {
+		description, err := dynFlags.DescribeJSON()
+		if err != nil {
+			return err
+		}
+		return &HelpRequested{Message: string(description) + "\n"}
+	}
+
 	return nil
 }
 
@@ -170,7 +174,12 @@ func main() {
 	// Parse everything
 	flagSet, dynFlags, err := parseFlags(args, version, output)
 	if err != nil {
-		// If the user requested help or version, print the message and exit
+		// Help has already been printed by dynflags
+		if errors.Is(err, dynflags.ErrHelp) {
+			return
+		}
+
+		// If the user requested the version or the flag description, print the message and exit
 		var hr *HelpRequested
 		if errors.As(err, &hr) {
 			fmt.Fprint(output, hr.Message) //nolint:errcheck
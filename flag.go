@@ -1,5 +1,7 @@
 package dynflags
 
+import "slices"
+
 type FlagType string
 
 const (
@@ -17,21 +19,71 @@ const (
 	FlagTypeIPSlice       FlagType = "..IPs"
 	FlagTypeURL           FlagType = "URL"
 	FlagTypeURLSlice      FlagType = "..URLs"
+	FlagTypeRef           FlagType = "REF"
 )
 
 // Flag represents a single configuration flag
 type Flag struct {
-	Default any       // Default value for the flag
-	Type    FlagType  // Type of the flag
-	Usage   string    // Description for usage
-	metaVar string    // MetaVar for flag
-	value   FlagValue // Encapsulated parsing and value-setting logic
+	Default    any                 // Default value for the flag
+	Type       FlagType            // Type of the flag
+	Usage      string              // Description for usage
+	metaVar    string              // MetaVar for flag
+	value      FlagValue           // Encapsulated parsing and value-setting logic
+	min        any                 // Lower bound for numeric and duration values
+	max        any                 // Upper bound for numeric and duration values
+	validators []func(v any) error // Custom validators run on parsed values
+	deprecated string              // Deprecation message; empty if the flag is not deprecated
+	hidden     bool                // Hide the flag from the help message
+	aliases    []string            // Alternative names, e.g. short names
+	group      *ConfigGroup        // Group the flag is defined in
+	name       string              // Name the flag is defined with
 }
 
 func (f *Flag) MetaVar(metaVar string) {
 	f.metaVar = metaVar
 }
 
+// Alias adds alternative names for the flag, e.g. a short name. Values passed with an alias
+// are stored under the name the flag was defined with. Aliases are listed in the help message.
+// It panics if an alias is already the name of a flag, an alias of another flag or the old name of a renamed flag.
+func (f *Flag) Alias(names ...string) *Flag {
+	for _, name := range names {
+		if f.group != nil {
+			f.group.checkFlagName(name, f)
+		}
+		if !slices.Contains(f.aliases, name) {
+			f.aliases = append(f.aliases, name)
+		}
+	}
+	return f
+}
+
+// Hidden hides the flag from the help message. The flag still parses.
+func (f *Flag) Hidden() *Flag {
+	f.hidden = true
+	return f
+}
+
+// shown reports whether the flag is shown in the help message; verbose includes hidden and deprecated flags.
+func (f *Flag) shown(verbose bool) bool {
+	return verbose || (!f.hidden && f.deprecated == "")
+}
+
+// metaVarOrType returns the MetaVar of the flag, falling back to its type.
+func (f *Flag) metaVarOrType() string {
+	if f.metaVar != "" {
+		return f.metaVar
+	}
+	return string(f.Type)
+}
+
+// Validate adds a validator that is run on every parsed value before it is stored.
+// For slice flags it is called once per passed element.
+func (f *Flag) Validate(validate func(v any) error) *Flag {
+	f.validators = append(f.validators, validate)
+	return f
+}
+
 // FlagValue interface encapsulates parsing and value-setting logic
 type FlagValue interface {
 	// Parse parses the given string value into the flag's value type
@@ -1,7 +1,10 @@
 package dynflags_test
 
 import (
+	"errors"
+	"net"
 	"testing"
+	"time"
 
 	"github.com/containeroo/dynflags"
 	"github.com/stretchr/testify/assert"
@@ -28,3 +31,147 @@ func TestFlagGetValue(t *testing.T) {
 		assert.Equal(t, "default-value", flag.GetValue(), "Expected GetValue() to return the default value")
 	})
 }
+
+func TestFlagValidate(t *testing.T) {
+	t.Parallel()
+
+	errAddress := errors.New("address must be host:port")
+	hostPort := func(v any) error {
+		if _, _, err := net.SplitHostPort(v.(string)); err != nil {
+			return errAddress
+		}
+		return nil
+	}
+
+	t.Run("Valid value is stored", func(t *testing.T) {
+		t.Parallel()
+
+		df := newExitOnError()
+		df.Group("tcp").String("address", "", "TCP address").Validate(hostPort)
+
+		err := df.Parse([]string{"--tcp.a.address=example.com:443"})
+		assert.NoError(t, err)
+		assert.Equal(t, "example.com:443", df.Parsed().Lookup("tcp").Lookup("a").Lookup("address"))
+	})
+
+	t.Run("Invalid value is rejected with flag context", func(t *testing.T) {
+		t.Parallel()
+
+		df := newExitOnError()
+		df.Group("tcp").String("address", "", "TCP address").Validate(hostPort)
+
+		err := df.Parse([]string{"--tcp.a.address=example.com"})
+		assert.EqualError(t, err, "invalid value for flag 'address' in group 'tcp' (identifier 'a'): address must be host:port")
+		assert.ErrorIs(t, err, errAddress)
+
+		var flagErr *dynflags.FlagError
+		assert.ErrorAs(t, err, &flagErr)
+		assert.Equal(t, "tcp", flagErr.Group)
+		assert.Equal(t, "a", flagErr.Identifier)
+		assert.Equal(t, "address", flagErr.Flag)
+		assert.Equal(t, "example.com", flagErr.Value)
+		assert.Nil(t, df.Parsed().Lookup("tcp").Lookup("a").Lookup("address"))
+	})
+
+	t.Run("Validators run per slice element", func(t *testing.T) {
+		t.Parallel()
+
+		var seen []any
+		df := dynflags.New(dynflags.ContinueOnError)
+		df.Group("http").IntSlices("status", nil, "Status codes").Validate(func(v any) error {
+			seen = append(seen, v)
+			return nil
+		})
+
+		err := df.Parse([]string{"--http.a.status=200", "--http.a.status=204"})
+		assert.NoError(t, err)
+		assert.Equal(t, []any{200, 204}, seen)
+	})
+
+	t.Run("Validators run in order", func(t *testing.T) {
+		t.Parallel()
+
+		df := newExitOnError()
+		df.Group("http").Int("retries", 0, "Retries").
+			Validate(func(v any) error { return errors.New("first") }).
+			Validate(func(v any) error { return errors.New("second") })
+
+		err := df.Parse([]string{"--http.a.retries=1"})
+		assert.EqualError(t, err, "invalid value for flag 'retries' in group 'http' (identifier 'a'): first")
+	})
+
+	t.Run("Parse errors carry flag context", func(t *testing.T) {
+		t.Parallel()
+
+		df := newExitOnError()
+		df.Group("http").Global().Int("concurrency", 1, "Concurrency")
+
+		err := df.Parse([]string{"--http.concurrency=many"})
+		var flagErr *dynflags.FlagError
+		assert.ErrorAs(t, err, &flagErr)
+		assert.Equal(t, "", flagErr.Identifier)
+		assert.Equal(t, "concurrency", flagErr.Flag)
+	})
+}
+
+func TestFlagAlias(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Alias parses into the canonical name", func(t *testing.T) {
+		t.Parallel()
+
+		df := newExitOnError()
+		http := df.Group("http")
+		http.Duration("timeout", time.Second, "HTTP timeout").Alias("t", "to")
+		http.String("method", "GET", "HTTP method")
+
+		args := []string{
+			"--http.a.t=5s",
+			"--http.b.to=3s",
+			"--http.c.timeout=1s",
+		}
+		err := df.Parse(args)
+		assert.NoError(t, err)
+
+		parsed := df.Parsed().Lookup("http")
+		assert.Equal(t, 5*time.Second, parsed.Lookup("a").Lookup("timeout"))
+		assert.Nil(t, parsed.Lookup("a").Lookup("t"))
+		assert.Equal(t, 3*time.Second, parsed.Lookup("b").Lookup("timeout"))
+		assert.Equal(t, 1*time.Second, parsed.Lookup("c").Lookup("timeout"))
+	})
+
+	t.Run("Colliding aliases panic", func(t *testing.T) {
+		t.Parallel()
+
+		df := newExitOnError()
+		http := df.Group("http")
+		http.String("t", "", "Other flag")
+		timeout := http.Duration("timeout", time.Second, "HTTP timeout").Alias("to")
+		http.String("method", "GET", "HTTP method").Alias("m")
+		http.Bool("insecure", false, "Skip TLS verification")
+		http.Alias("skip-tls-verify", "insecure")
+
+		assert.PanicsWithValue(t, "dynflags: alias 't' of flag 'timeout' collides with a flag in group 'http'", func() { timeout.Alias("t") })
+		assert.PanicsWithValue(t, "dynflags: alias 'm' of flag 'timeout' collides with an alias of flag 'method' in group 'http'", func() { timeout.Alias("m") })
+		assert.PanicsWithValue(t, "dynflags: alias 'skip-tls-verify' of flag 'timeout' collides with the old name of renamed flag 'insecure' in group 'http'", func() { timeout.Alias("skip-tls-verify") })
+		assert.PanicsWithValue(t, "dynflags: flag 'm' collides with an alias of flag 'method' in group 'http'", func() { http.String("m", "", "Other flag") })
+		assert.NotPanics(t, func() { timeout.Alias("to") })
+
+		err := df.Parse([]string{"--http.a.t=fast", "--http.a.to=5s"})
+		assert.NoError(t, err)
+		assert.Equal(t, "fast", df.Parsed().Lookup("http").Lookup("a").Lookup("t"))
+		assert.Equal(t, 5*time.Second, df.Parsed().Lookup("http").Lookup("a").Lookup("timeout"))
+	})
+
+	t.Run("Errors name the canonical flag", func(t *testing.T) {
+		t.Parallel()
+
+		df := newExitOnError()
+		df.Group("http").Duration("timeout", time.Second, "HTTP timeout").Alias("t")
+
+		err := df.Parse([]string{"--http.a.t=soon"})
+		var flagErr *dynflags.FlagError
+		assert.ErrorAs(t, err, &flagErr)
+		assert.Equal(t, "timeout", flagErr.Flag)
+	})
+}
@@ -1,11 +1,27 @@
 package dynflags
 
+import (
+	"fmt"
+	"slices"
+)
+
 // ConfigGroup represents the static configuration for a group.
 type ConfigGroup struct {
-	Name      string           // Name of the group.
-	usage     string           // Title for usage. If not set it takes the name of the group in Uppercase.
-	Flags     map[string]*Flag // Flags within the group.
-	flagOrder []string         // Order of flags.
+	Name           string                  // Name of the group.
+	usage          string                  // Title for usage. If not set it takes the name of the group in Uppercase.
+	Flags          map[string]*Flag        // Flags within the group.
+	flagOrder      []string                // Order of flags.
+	groups         map[string]*ConfigGroup // Child groups, addressed below an identifier of this group.
+	groupOrder     []string                // Order of child groups.
+	global         *ConfigGroup            // Group-level flags, addressed without identifier.
+	rules          []rule                  // Rules between flags, checked per identifier after parsing.
+	minIdentifiers int                     // Minimum number of identifiers.
+	maxIdentifiers int                     // Maximum number of identifiers; zero means unlimited.
+	aliases        map[string]string       // Deprecated flag names mapped to their new names.
+	aliasOrder     []string                // Order of aliases.
+	hidden         bool                    // Hide the group from the help message.
+	df             *DynFlags               // Instance the group was defined on.
+	parent         *ConfigGroup            // Group a child group or the group-level flags belong to; nil for top-level groups.
 }
 
 // Usage sets the usage for the group.
@@ -22,6 +38,169 @@ func (gc *ConfigGroup) Lookup(flagName string) *Flag {
 	return gc.Flags[flagName]
 }
 
+// Group defines a child group or retrieves an existing one.
+// Flags of a child group are addressed as --<group>.<identifier>.<child>.<identifier>.<flag>.
+// It panics on the group-level flags returned by Global.
+func (cg *ConfigGroup) Group(name string) *ConfigGroup {
+	cg.checkNotGlobal("child groups")
+	if group, exists := cg.groups[name]; exists {
+		return group
+	}
+
+	if cg.groups == nil {
+		cg.groups = make(map[string]*ConfigGroup)
+	}
+	cg.groupOrder = append(cg.groupOrder, name)
+	group := &ConfigGroup{
+		Name:   name,
+		Flags:  make(map[string]*Flag),
+		df:     cg.df,
+		parent: cg,
+	}
+	cg.groups[name] = group
+	return group
+}
+
+// Global returns the group-level flags of the group.
+// They apply to the whole group and are addressed without identifier as --<group>.<flag>.
+// Child groups and identifier limits are not supported on them; Group, Global, MinIdentifiers
+// and MaxIdentifiers panic when called on the returned group.
+func (cg *ConfigGroup) Global() *ConfigGroup {
+	cg.checkNotGlobal("group-level flags")
+	if cg.global == nil {
+		cg.global = &ConfigGroup{
+			Name:   cg.Name,
+			Flags:  make(map[string]*Flag),
+			df:     cg.df,
+			parent: cg,
+		}
+	}
+	return cg.global
+}
+
+// isGlobal reports whether the group holds the group-level flags of its parent.
+func (cg *ConfigGroup) isGlobal() bool {
+	return cg.parent != nil && cg.parent.global == cg
+}
+
+// checkNotGlobal panics if the group holds group-level flags, which do not support the given feature.
+func (cg *ConfigGroup) checkNotGlobal(feature string) {
+	if cg.isGlobal() {
+		panic(fmt.Sprintf("dynflags: group-level flags of group '%s' do not support %s", cg.Name, feature))
+	}
+}
+
+// Sub retrieves a child group by its name.
+func (cg *ConfigGroup) Sub(groupName string) *ConfigGroup {
+	if cg == nil {
+		return nil
+	}
+
+	return cg.groups[groupName]
+}
+
+// Groups returns the child groups for direct iteration.
+func (cg *ConfigGroup) Groups() map[string]*ConfigGroup {
+	if cg == nil {
+		return nil
+	}
+
+	return cg.groups
+}
+
+// addFlag adds a flag to the group. It panics if the name is an alias of another flag.
+func (cg *ConfigGroup) addFlag(name string, flag *Flag) {
+	cg.checkFlagName(name, nil)
+	flag.group, flag.name = cg, name
+	cg.Flags[name] = flag
+	cg.flagOrder = append(cg.flagOrder, name)
+}
+
+// checkFlagName panics if the name is already an alias of another flag or the old name of a renamed flag.
+// For an alias of flag, the name must also not be the name of a flag.
+func (cg *ConfigGroup) checkFlagName(name string, flag *Flag) {
+	what := fmt.Sprintf("flag '%s'", name)
+	if flag != nil {
+		what = fmt.Sprintf("alias '%s' of flag '%s'", name, flag.name)
+		if _, exists := cg.Flags[name]; exists {
+			panic(fmt.Sprintf("dynflags: %s collides with a flag in group '%s'", what, cg.Name))
+		}
+	}
+	if flagName, existing := cg.lookupAlias(name); existing != nil && existing != flag {
+		panic(fmt.Sprintf("dynflags: %s collides with an alias of flag '%s' in group '%s'", what, flagName, cg.Name))
+	}
+	if newName, exists := cg.aliases[name]; exists {
+		panic(fmt.Sprintf("dynflags: %s collides with the old name of renamed flag '%s' in group '%s'", what, newName, cg.Name))
+	}
+}
+
+// lookupAlias retrieves a flag by one of its aliases.
+func (cg *ConfigGroup) lookupAlias(alias string) (string, *Flag) {
+	for _, flagName := range cg.flagOrder {
+		if slices.Contains(cg.Flags[flagName].aliases, alias) {
+			return flagName, cg.Flags[flagName]
+		}
+	}
+	return "", nil
+}
+
+// resolveFlag retrieves a flag by its name or by an alias.
+// It returns the name the flag is defined with and a warning if a deprecated name was used.
+func (cg *ConfigGroup) resolveFlag(groupPath, flagName string) (name string, flag *Flag, warning string) {
+	if cg == nil {
+		return "", nil, ""
+	}
+
+	name = flagName
+	if newName, ok := cg.aliases[flagName]; ok {
+		name = newName
+		warning = fmt.Sprintf("flag '%s' in group '%s' is deprecated, use '%s' instead", flagName, groupPath, newName)
+	}
+
+	flag = cg.Flags[name]
+	if flag == nil {
+		name, flag = cg.lookupAlias(flagName)
+	}
+	if flag != nil && flag.deprecated != "" && warning == "" {
+		warning = fmt.Sprintf("flag '%s' in group '%s' is deprecated: %s", flagName, groupPath, flag.deprecated)
+	}
+	return name, flag, warning
+}
+
+// Hidden hides the group from the help message. Its flags still parse.
+func (cg *ConfigGroup) Hidden() {
+	cg.hidden = true
+}
+
+// shown reports whether the group is shown in the help message; verbose includes hidden groups.
+func (cg *ConfigGroup) shown(verbose bool) bool {
+	return cg != nil && (verbose || !cg.hidden)
+}
+
+// hasFlags reports whether the group defines flags shown in the help message,
+// either as group-level flags, for its identifiers or in its child groups.
+func (cg *ConfigGroup) hasFlags(verbose bool) bool {
+	return cg.shown(verbose) && (cg.global.hasIdentifierFlags(verbose) || cg.hasIdentifierFlags(verbose))
+}
+
+// hasIdentifierFlags reports whether the group or its child groups define flags shown in the help message.
+func (cg *ConfigGroup) hasIdentifierFlags(verbose bool) bool {
+	if !cg.shown(verbose) {
+		return false
+	}
+	for _, flag := range cg.Flags {
+		if flag.shown(verbose) {
+			return true
+		}
+	}
+	for _, group := range cg.groups {
+		if group.hasFlags(verbose) {
+			return true
+		}
+	}
+	return false
+}
+
 // ConfigGroups represents all configuration groups with lookup and iteration support.
 type ConfigGroups struct {
 	groups map[string]*ConfigGroup
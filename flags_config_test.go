@@ -133,3 +133,49 @@ func TestDynFlags_Config_NilHandling(t *testing.T) {
 		assert.Nil(t, result, "Expected Config on nil DynFlags to return nil")
 	})
 }
+
+func TestConfigGroup_ChildGroups(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Define and retrieve child group", func(t *testing.T) {
+		t.Parallel()
+
+		group := &dynflags.ConfigGroup{
+			Name:  "cluster",
+			Flags: map[string]*dynflags.Flag{},
+		}
+
+		node := group.Group("node")
+		assert.NotNil(t, node)
+		assert.Equal(t, "node", node.Name)
+		assert.NotNil(t, node.Flags)
+		assert.Equal(t, node, group.Group("node"))
+		assert.Equal(t, node, group.Sub("node"))
+		assert.Contains(t, group.Groups(), "node")
+	})
+
+	t.Run("Group-level flags reject child groups and identifier limits", func(t *testing.T) {
+		t.Parallel()
+
+		group := &dynflags.ConfigGroup{
+			Name:  "cluster",
+			Flags: map[string]*dynflags.Flag{},
+		}
+		global := group.Global()
+		assert.Equal(t, global, group.Global())
+
+		assert.PanicsWithValue(t, "dynflags: group-level flags of group 'cluster' do not support child groups", func() { global.Group("node") })
+		assert.PanicsWithValue(t, "dynflags: group-level flags of group 'cluster' do not support group-level flags", func() { global.Global() })
+		assert.PanicsWithValue(t, "dynflags: group-level flags of group 'cluster' do not support identifier limits", func() { global.MinIdentifiers(1) })
+		assert.PanicsWithValue(t, "dynflags: group-level flags of group 'cluster' do not support identifier limits", func() { global.MaxIdentifiers(1) })
+		assert.NotPanics(t, func() { global.Hidden() })
+	})
+
+	t.Run("Sub on nil ConfigGroup returns nil", func(t *testing.T) {
+		t.Parallel()
+
+		var group *dynflags.ConfigGroup
+		assert.Nil(t, group.Sub("node"))
+		assert.Nil(t, group.Groups())
+	})
+}
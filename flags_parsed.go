@@ -1,5 +1,7 @@
 package dynflags
 
+import "maps"
+
 // GroupsMap is a map of group name -> IdentifiersMap.
 type GroupsMap map[string]IdentifiersMap
 
@@ -8,9 +10,12 @@ type IdentifiersMap map[string]*ParsedGroup
 
 // ParsedGroup represents a runtime group with parsed values.
 type ParsedGroup struct {
-	Parent *ConfigGroup   // Reference to the parent static group.
-	Name   string         // Identifier for the child group (e.g., "IDENTIFIER1").
-	Values map[string]any // Parsed values for the group's flags.
+	Parent  *ConfigGroup            // Reference to the parent static group.
+	Name    string                  // Identifier for the child group (e.g., "IDENTIFIER1").
+	Values  map[string]any          // Parsed values for the group's flags.
+	groups  GroupsMap               // Parsed child groups below this identifier.
+	globals map[string]*ParsedGroup // Parsed group-level flags of the child groups.
+	refs    map[string]*ParsedGroup // Resolved targets of Ref flags.
 }
 
 // Lookup retrieves the value of a flag in the parsed group.
@@ -21,9 +26,26 @@ func (g *ParsedGroup) Lookup(flagName string) any {
 	return g.Values[flagName]
 }
 
+// Sub retrieves a parsed child group by its name.
+func (g *ParsedGroup) Sub(groupName string) *ParsedIdentifiers {
+	if g == nil {
+		return nil
+	}
+	return newParsedIdentifiers(groupName, g.groups, g.globals)
+}
+
+// Groups returns the parsed child groups for direct iteration.
+func (g *ParsedGroup) Groups() GroupsMap {
+	if g == nil {
+		return nil
+	}
+	return g.groups
+}
+
 // ParsedGroups represents all parsed groups with lookup and iteration support.
 type ParsedGroups struct {
-	groups GroupsMap // Nested map of group name -> IdentifiersMap.
+	groups  GroupsMap               // Nested map of group name -> IdentifiersMap.
+	globals map[string]*ParsedGroup // Group-level flags by group name.
 }
 
 // Lookup retrieves a group by its name.
@@ -31,10 +53,7 @@ func (g *ParsedGroups) Lookup(groupName string) *ParsedIdentifiers {
 	if g == nil {
 		return nil
 	}
-	if identifiers, exists := g.groups[groupName]; exists {
-		return &ParsedIdentifiers{Name: groupName, identifiers: identifiers}
-	}
-	return nil
+	return newParsedIdentifiers(groupName, g.groups, g.globals)
 }
 
 // Groups returns the underlying GroupsMap for direct iteration.
@@ -46,6 +65,17 @@ func (g *ParsedGroups) Groups() GroupsMap {
 type ParsedIdentifiers struct {
 	Name        string
 	identifiers IdentifiersMap
+	global      *ParsedGroup
+}
+
+// newParsedIdentifiers returns the parsed identifiers of a group, or nil if the group was not parsed.
+func newParsedIdentifiers(groupName string, groups GroupsMap, globals map[string]*ParsedGroup) *ParsedIdentifiers {
+	identifiers, exists := groups[groupName]
+	global := globals[groupName]
+	if !exists && global == nil {
+		return nil
+	}
+	return &ParsedIdentifiers{Name: groupName, identifiers: identifiers, global: global}
 }
 
 // Lookup retrieves a specific identifier within a group.
@@ -56,6 +86,14 @@ func (i *ParsedIdentifiers) Lookup(identifier string) *ParsedGroup {
 	return i.identifiers[identifier]
 }
 
+// Global retrieves the parsed group-level flags of the group.
+func (i *ParsedIdentifiers) Global() *ParsedGroup {
+	if i == nil {
+		return nil
+	}
+	return i.global
+}
+
 // Parsed returns a ParsedGroups instance for the dynflags instance.
 func (f *DynFlags) Parsed() *ParsedGroups {
 	parsed := make(GroupsMap)
@@ -66,5 +104,53 @@ func (f *DynFlags) Parsed() *ParsedGroups {
 		}
 		parsed[groupName] = identifierMap
 	}
-	return &ParsedGroups{groups: parsed}
+	return &ParsedGroups{groups: parsed, globals: f.parsedGlobals}
+}
+
+// childMaps returns the maps holding the parsed child groups, initializing them if needed.
+func (g *ParsedGroup) childMaps() (GroupsMap, map[string]*ParsedGroup) {
+	if g.groups == nil {
+		g.groups = make(GroupsMap)
+	}
+	if g.globals == nil {
+		g.globals = make(map[string]*ParsedGroup)
+	}
+	return g.groups, g.globals
+}
+
+// inherit copies all values of src, including those of its child groups, that are not set in g.
+// Values of flags that are mutually exclusive with a flag set in g are not copied.
+func (g *ParsedGroup) inherit(src *ParsedGroup) {
+	own := maps.Clone(g.Values)
+	for flagName, value := range src.Values {
+		if _, set := own[flagName]; !set && !g.Parent.excludes(flagName, own) {
+			g.Values[flagName] = value
+		}
+	}
+	if len(src.groups) == 0 && len(src.globals) == 0 {
+		return
+	}
+
+	groups, globals := g.childMaps()
+	for groupName, identifiers := range src.groups {
+		if _, exists := groups[groupName]; !exists {
+			groups[groupName] = make(IdentifiersMap)
+		}
+		for identifier, child := range identifiers {
+			target, exists := groups[groupName][identifier]
+			if !exists {
+				target = &ParsedGroup{Parent: child.Parent, Name: identifier, Values: make(map[string]any)}
+				groups[groupName][identifier] = target
+			}
+			target.inherit(child)
+		}
+	}
+	for groupName, global := range src.globals {
+		target, exists := globals[groupName]
+		if !exists {
+			target = &ParsedGroup{Parent: global.Parent, Name: groupName, Values: make(map[string]any)}
+			globals[groupName] = target
+		}
+		target.inherit(global)
+	}
 }
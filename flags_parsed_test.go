@@ -165,3 +165,44 @@ func TestParsedGroup_Lookup_NilHandling(t *testing.T) {
 		assert.Nil(t, result, "Expected Lookup for non-existing flag to return nil")
 	})
 }
+
+func TestParsedGroup_Sub_NilHandling(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Sub on nil ParsedGroup returns nil", func(t *testing.T) {
+		t.Parallel()
+
+		var parsedGroup *dynflags.ParsedGroup
+		assert.Nil(t, parsedGroup.Sub("node"))
+		assert.Nil(t, parsedGroup.Groups())
+	})
+
+	t.Run("Sub non-existing group returns nil", func(t *testing.T) {
+		t.Parallel()
+
+		parsedGroup := &dynflags.ParsedGroup{
+			Name:   "identifier1",
+			Values: map[string]any{},
+		}
+
+		assert.Nil(t, parsedGroup.Sub("node"))
+	})
+}
+
+func TestParsedIdentifiers_Global_NilHandling(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Global on nil ParsedIdentifiers returns nil", func(t *testing.T) {
+		t.Parallel()
+
+		var parsedIdentifiers *dynflags.ParsedIdentifiers
+		assert.Nil(t, parsedIdentifiers.Global())
+	})
+
+	t.Run("Global without group-level flags returns nil", func(t *testing.T) {
+		t.Parallel()
+
+		parsedIdentifiers := &dynflags.ParsedIdentifiers{}
+		assert.Nil(t, parsedIdentifiers.Global())
+	})
+}
@@ -33,13 +33,12 @@ func (i *Float64Value) Set(value any) error {
 func (g *ConfigGroup) Float64(name string, value float64, usage string) *Flag {
 	bound := &value
 	flag := &Flag{
-		Type:    FlagTypeInt,
+		Type:    FlagTypeFloat,
 		Default: value,
 		Usage:   usage,
 		value:   &Float64Value{Bound: bound},
 	}
-	g.Flags[name] = flag
-	g.flagOrder = append(g.flagOrder, name)
+	g.addFlag(name, flag)
 	return flag
 }
 
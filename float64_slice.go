@@ -48,8 +48,7 @@ func (g *ConfigGroup) Float64Slices(name string, value []float64, usage string)
 		Usage:   usage,
 		value:   &Float64SlicesValue{Bound: bound},
 	}
-	g.Flags[name] = flag
-	g.flagOrder = append(g.flagOrder, name)
+	g.addFlag(name, flag)
 	return flag
 }
 
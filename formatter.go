@@ -0,0 +1,153 @@
+package dynflags
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"unicode/utf8"
+)
+
+// HelpFormatter renders the help message.
+type HelpFormatter interface {
+	// FormatHelp writes the help message described by the model to w.
+	FormatHelp(w io.Writer, model *HelpModel) error
+}
+
+// HelpFormatterFunc adapts a function to the HelpFormatter interface.
+type HelpFormatterFunc func(w io.Writer, model *HelpModel) error
+
+// FormatHelp calls f(w, model).
+func (f HelpFormatterFunc) FormatHelp(w io.Writer, model *HelpModel) error {
+	return f(w, model)
+}
+
+// SetHelpFormatter sets the formatter used by PrintDefaults and the group help (default DefaultHelpFormatter).
+func (df *DynFlags) SetHelpFormatter(formatter HelpFormatter) {
+	df.formatter = formatter
+}
+
+// DefaultHelpFormatter renders the help message as aligned "Flag" and "Usage" columns per group.
+type DefaultHelpFormatter struct{}
+
+// FormatHelp writes the help message described by the model to out.
+func (DefaultHelpFormatter) FormatHelp(out io.Writer, model *HelpModel) error {
+	w := tabwriter.NewWriter(out, 0, 8, 2, ' ', 0)
+
+	// Print title if present
+	if model.Title != "" {
+		fmt.Fprintln(out, model.Title) // nolint:errcheck
+		fmt.Fprintln(out)              // nolint:errcheck
+	}
+
+	// Print description if present
+	if model.Description != "" {
+		fmt.Fprintln(out, model.Description) // nolint:errcheck
+		fmt.Fprintln(out)                    // nolint:errcheck
+	}
+
+	for _, group := range model.Groups {
+		// Print group usage or fallback to uppercase group name
+		if group.Usage != "" {
+			fmt.Fprintln(w, group.Usage) // nolint:errcheck
+		} else {
+			fmt.Fprintln(w, strings.ToUpper(group.Name)) // nolint:errcheck
+		}
+
+		if len(group.Flags) == 0 {
+			continue
+		}
+		fmt.Fprintln(w, "  Flag\tUsage") // nolint:errcheck
+		columns := usageColumns(group.Flags)
+		for i, flag := range group.Flags {
+			// Group-level flags are separated from the flags of the identifiers
+			if i > 0 && separated(group.Flags[i-1], flag) {
+				fmt.Fprintln(w, "") // nolint:errcheck
+			}
+
+			width := 0
+			if model.Width > 0 {
+				width = max(model.Width-columns[i], minUsageWidth)
+			}
+			lines := wrapText(flagUsage(flag), width)
+			fmt.Fprintf(w, "  %s %s\t%s\n", flagKeys(flag), flag.MetaVar, lines[0]) // nolint:errcheck
+			// Continuation lines are aligned with the usage column
+			for _, line := range lines[1:] {
+				fmt.Fprintf(w, "\t%s\n", line) // nolint:errcheck
+			}
+
+			// Deprecated names are listed below the flag they refer to
+			for _, oldKey := range flag.DeprecatedKeys {
+				fmt.Fprintf(w, "  %s %s\tDeprecated, use %s instead\n", oldKey, flag.MetaVar, flag.Key) // nolint:errcheck
+			}
+		}
+		fmt.Fprintln(w, "") // nolint:errcheck
+	}
+
+	// tabwriter buffers output for alignment; flush now to ensure aligned flag output is printed before the epilog
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	// Print epilog if present
+	if model.Epilog != "" {
+		fmt.Fprintln(out)               // nolint:errcheck
+		fmt.Fprintln(out, model.Epilog) // nolint:errcheck
+	}
+	return nil
+}
+
+// separated reports whether a blank line separates the group-level flags from the flags of the identifiers.
+func separated(prev, flag HelpFlag) bool {
+	return prev.Global && !flag.Global && prev.Group == flag.Group
+}
+
+// flagKeys returns the key of a flag followed by its aliases.
+func flagKeys(flag HelpFlag) string {
+	return strings.Join(append([]string{flag.Key}, flag.Aliases...), ", ")
+}
+
+// usageColumns returns the column the usage of each flag starts at. Like the tabwriter,
+// it aligns consecutive lines; the first block includes the "Flag" header.
+func usageColumns(flags []HelpFlag) []int {
+	const padding = 2
+
+	columns := make([]int, len(flags))
+	start, width := 0, utf8.RuneCountInString("  Flag")
+	for i := 0; i <= len(flags); i++ {
+		if i == len(flags) || (i > 0 && separated(flags[i-1], flags[i])) {
+			for j := start; j < i; j++ {
+				columns[j] = width + padding
+			}
+			start, width = i, 0
+		}
+		if i == len(flags) {
+			break
+		}
+
+		flag := flags[i]
+		width = max(width, utf8.RuneCountInString(fmt.Sprintf("  %s %s", flagKeys(flag), flag.MetaVar)))
+		for _, oldKey := range flag.DeprecatedKeys {
+			width = max(width, utf8.RuneCountInString(fmt.Sprintf("  %s %s", oldKey, flag.MetaVar)))
+		}
+	}
+	return columns
+}
+
+// flagUsage returns the usage of a flag with its default, range and state appended.
+func flagUsage(flag HelpFlag) string {
+	usage := flag.Usage
+	if flag.Default != nil && flag.Default != "" {
+		usage = fmt.Sprintf("%s (default: %v)", flag.Usage, flag.Default)
+	}
+	if flag.Range != "" {
+		usage = fmt.Sprintf("%s %s", usage, flag.Range)
+	}
+	if flag.Deprecated != "" {
+		usage = fmt.Sprintf("%s (deprecated: %s)", usage, flag.Deprecated)
+	}
+	if flag.Hidden {
+		usage = fmt.Sprintf("%s (hidden)", usage)
+	}
+	return usage
+}
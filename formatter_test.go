@@ -0,0 +1,161 @@
+package dynflags_test
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/containeroo/dynflags"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHelpModel(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Model describes groups and flags", func(t *testing.T) {
+		t.Parallel()
+
+		df := dynflags.New(dynflags.ContinueOnError)
+		df.Title("Title")
+		df.Description("Description")
+		df.Epilog("Epilog")
+		http := df.Group("http")
+		http.Usage("HTTP flags")
+		http.Global().Int("concurrency", 1, "Concurrency")
+		http.Duration("timeout", time.Second, "Timeout").Range(time.Second, time.Minute).Alias("t")
+		http.String("verb", "GET", "Verb").Deprecated("use method")
+		http.String("method", "GET", "Method")
+		http.Alias("request-method", "method")
+		http.Group("header").String("value", "", "Header value")
+
+		model := df.HelpModel()
+		assert.Equal(t, "Title", model.Title)
+		assert.Equal(t, "Description", model.Description)
+		assert.Equal(t, "Epilog", model.Epilog)
+		assert.Equal(t, "--<group>.<identifier>.<flag>", model.Pattern)
+		assert.Len(t, model.Groups, 1)
+
+		group := model.Groups[0]
+		assert.Equal(t, "http", group.Name)
+		assert.Equal(t, "HTTP flags", group.Usage)
+		assert.Len(t, group.Flags, 4)
+
+		assert.Equal(t, dynflags.HelpFlag{
+			Name:    "concurrency",
+			Group:   "http",
+			Global:  true,
+			Key:     "--http.concurrency",
+			MetaVar: "INT",
+			Type:    dynflags.FlagTypeInt,
+			Default: 1,
+			Usage:   "Concurrency",
+		}, group.Flags[0])
+		assert.Equal(t, dynflags.HelpFlag{
+			Name:    "timeout",
+			Group:   "http",
+			Key:     "--http.<IDENTIFIER>.timeout",
+			Aliases: []string{"--http.<IDENTIFIER>.t"},
+			MetaVar: "DURATION",
+			Type:    dynflags.FlagTypeDuration,
+			Default: time.Second,
+			Usage:   "Timeout",
+			Range:   "[1s..1m]",
+			Min:     time.Second,
+			Max:     time.Minute,
+		}, group.Flags[1])
+		assert.Equal(t, "method", group.Flags[2].Name)
+		assert.Empty(t, group.Flags[2].DeprecatedKeys, "deprecated names are only listed in verbose help")
+		assert.Equal(t, "http.header", group.Flags[3].Group)
+		assert.Equal(t, "--http.<IDENTIFIER>.header.<IDENTIFIER>.value", group.Flags[3].Key)
+	})
+
+	t.Run("Verbose model includes hidden and deprecated entries", func(t *testing.T) {
+		t.Parallel()
+
+		df := dynflags.New(dynflags.ContinueOnError)
+		df.VerboseHelp = true
+		http := df.Group("http")
+		http.String("secret", "", "Secret").Hidden()
+		http.String("verb", "GET", "Verb").Deprecated("use method")
+		http.String("method", "GET", "Method")
+		http.Alias("request-method", "method")
+		df.Group("debug").Hidden()
+
+		model := df.HelpModel()
+		assert.Len(t, model.Groups, 2)
+		assert.True(t, model.Groups[1].Hidden)
+
+		flags := model.Groups[0].Flags
+		assert.Len(t, flags, 3)
+		assert.True(t, flags[0].Hidden)
+		assert.Equal(t, "use method", flags[1].Deprecated)
+		assert.Equal(t, []string{"--http.<IDENTIFIER>.request-method"}, flags[2].DeprecatedKeys)
+	})
+}
+
+func TestHelpFormatter(t *testing.T) {
+	t.Parallel()
+
+	newFlags := func() (*dynflags.DynFlags, *bytes.Buffer) {
+		var buf bytes.Buffer
+		df := dynflags.New(dynflags.ContinueOnError)
+		df.SetOutput(&buf)
+		df.Title("My App")
+		http := df.Group("http")
+		http.String("method", "GET", "HTTP method")
+		http.Int("retries", 3, "Retries")
+		df.Group("tcp").String("address", "", "TCP address")
+		return df, &buf
+	}
+
+	formatter := dynflags.HelpFormatterFunc(func(w io.Writer, model *dynflags.HelpModel) error {
+		fmt.Fprintf(w, "# %s\n", model.Title) // nolint:errcheck
+		for _, group := range model.Groups {
+			fmt.Fprintf(w, "[%s]\n", group.Name) // nolint:errcheck
+			for _, flag := range group.Flags {
+				fmt.Fprintf(w, "%s=%v\n", flag.Key, flag.Default) // nolint:errcheck
+			}
+		}
+		return nil
+	})
+
+	t.Run("Custom formatter renders PrintDefaults", func(t *testing.T) {
+		t.Parallel()
+
+		df, buf := newFlags()
+		df.SetHelpFormatter(formatter)
+		df.PrintDefaults()
+
+		expected := "# My App\n" +
+			"[http]\n" +
+			"--http.<IDENTIFIER>.method=GET\n" +
+			"--http.<IDENTIFIER>.retries=3\n" +
+			"[tcp]\n" +
+			"--tcp.<IDENTIFIER>.address=\n"
+		assert.Equal(t, expected, buf.String())
+	})
+
+	t.Run("Custom formatter renders group help", func(t *testing.T) {
+		t.Parallel()
+
+		df, buf := newFlags()
+		df.SetHelpFormatter(formatter)
+		assert.NoError(t, df.PrintGroupDefaults("tcp"))
+
+		assert.Equal(t, "# \n[tcp]\n--tcp.<IDENTIFIER>.address=\n", buf.String())
+	})
+
+	t.Run("Default formatter matches PrintDefaults", func(t *testing.T) {
+		t.Parallel()
+
+		df, buf := newFlags()
+		df.PrintDefaults()
+
+		var formatted bytes.Buffer
+		err := dynflags.DefaultHelpFormatter{}.FormatHelp(&formatted, df.HelpModel())
+		assert.NoError(t, err)
+		assert.Equal(t, buf.String(), formatted.String())
+	})
+}
@@ -0,0 +1,60 @@
+package dynflags
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ErrHelp is returned by Parse if help was requested, e.g. with "--help" or "--http.help".
+var ErrHelp = errors.New("dynflags: help requested")
+
+// SetHelpFlags sets the names of the flags requesting help (default "help" and "h").
+// They are recognized with one or two leading dashes, e.g. "--help" or "-h", and as the
+// flag of a group for the help of that group only, e.g. "--http.help" (with the configured prefix).
+// Without names, help flags are not recognized.
+func (df *DynFlags) SetHelpFlags(names ...string) {
+	df.helpFlags = names
+}
+
+// SetUsage sets the function printing the help when it is requested or ExitOnError is set.
+func (df *DynFlags) SetUsage(usage func()) {
+	df.usage = usage
+}
+
+// handleHelp prints the help if the argument requests it and reports whether it did.
+// The full help is requested with one or two dashes regardless of the prefix style,
+// the help of a group with the configured prefix.
+func (df *DynFlags) handleHelp(arg string) bool {
+	if len(df.helpFlags) == 0 {
+		return false
+	}
+
+	if name, ok := strings.CutPrefix(arg, "-"); ok && slices.Contains(df.helpFlags, strings.TrimPrefix(name, "-")) {
+		df.usage()
+		return true
+	}
+
+	key, ok := df.trimPrefix(arg)
+	if !ok {
+		return false
+	}
+	path, err := splitPath(key, df.separator)
+	if err != nil || len(path) != 2 || !slices.Contains(df.helpFlags, path[1]) {
+		return false
+	}
+	groupName, _ := df.resolveGroup(path[0])
+	group := df.configGroups[groupName]
+	if group == nil {
+		return false
+	}
+	// A group-level flag with the same name takes precedence
+	if _, flag, _ := group.global.resolveFlag(groupName, path[1]); flag != nil {
+		return false
+	}
+
+	fmt.Fprintf(df.output, "Usage: [%s%s value]\n\n", df.argPrefix(), df.keyPattern(groupName, "<identifier>", "<flag>")) // nolint:errcheck
+	df.printGroupDefaults(df.output, group)
+	return true
+}
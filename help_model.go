@@ -0,0 +1,211 @@
+package dynflags
+
+import (
+	"slices"
+	"sort"
+)
+
+// HelpModel is the structured content of the help message, as rendered by a HelpFormatter.
+type HelpModel struct {
+	Title       string      `json:"title,omitempty"`       // Title of the help message
+	Description string      `json:"description,omitempty"` // Description after the title
+	Epilog      string      `json:"epilog,omitempty"`      // Epilog after the groups
+	Pattern     string      `json:"pattern"`               // Pattern of the keys, e.g. "--<group>.<identifier>.<flag>"
+	Width       int         `json:"-"`                     // Line width to wrap the usage text to; zero disables wrapping
+	Groups      []HelpGroup `json:"groups"`                // Groups in the order they are printed
+}
+
+// HelpGroup is a group in the help message.
+type HelpGroup struct {
+	Name   string      `json:"name"`             // Name of the group
+	Usage  string      `json:"usage,omitempty"`  // Usage of the group; empty if not set
+	Hidden bool        `json:"hidden,omitempty"` // The group is hidden and only shown with VerboseHelp
+	Flags  []HelpFlag  `json:"flags,omitempty"`  // Flags of the group and its child groups in the order they are printed
+	Rules  []HelpRule  `json:"rules,omitempty"`  // Rules between the flags of the group and its child groups
+	Limits []HelpLimit `json:"limits,omitempty"` // Identifier limits of the group and its child groups
+}
+
+// HelpRule is a rule between flags of the same identifier, declared with
+// MutuallyExclusive ("mutuallyExclusive"), RequiredTogether ("requiredTogether") or OneRequired ("oneRequired").
+type HelpRule struct {
+	Group  string   `json:"group"`            // Group the rule belongs to; child groups are joined by the separator
+	Global bool     `json:"global,omitempty"` // The rule applies to the group-level flags
+	Kind   string   `json:"kind"`             // Kind of the rule
+	Flags  []string `json:"flags"`            // Names of the flags of the rule
+}
+
+// HelpLimit is the number of identifiers a group accepts, declared with MinIdentifiers and MaxIdentifiers.
+type HelpLimit struct {
+	Group string `json:"group"`         // Group the limit belongs to; child groups are joined by the separator
+	Min   int    `json:"min,omitempty"` // Minimum number of identifiers
+	Max   int    `json:"max,omitempty"` // Maximum number of identifiers; zero means unlimited
+}
+
+// HelpFlag is a flag in the help message.
+type HelpFlag struct {
+	Name           string   `json:"name"`                     // Name of the flag
+	Group          string   `json:"group"`                    // Group the flag belongs to; child groups are joined by the separator
+	Global         bool     `json:"global,omitempty"`         // The flag is a group-level flag, addressed without identifier
+	Key            string   `json:"key"`                      // Key including the prefix, e.g. "--http.<IDENTIFIER>.method"
+	Aliases        []string `json:"aliases,omitempty"`        // Keys of the aliases of the flag
+	DeprecatedKeys []string `json:"deprecatedKeys,omitempty"` // Keys of deprecated names the flag was renamed from
+	MetaVar        string   `json:"metaVar"`                  // MetaVar of the flag, falling back to its type
+	Type           FlagType `json:"type"`                     // Type of the flag
+	Default        any      `json:"default,omitempty"`        // Default value
+	Usage          string   `json:"usage,omitempty"`          // Description of the flag
+	Range          string   `json:"-"`                        // Allowed range as printed, e.g. "[1..10]"; empty if unbounded
+	Min            any      `json:"min,omitempty"`            // Lower bound of the values; nil if unbounded
+	Max            any      `json:"max,omitempty"`            // Upper bound of the values; nil if unbounded
+	Deprecated     string   `json:"deprecated,omitempty"`     // Deprecation message; empty if the flag is not deprecated
+	Hidden         bool     `json:"hidden,omitempty"`         // The flag or its child group is hidden and only shown with VerboseHelp
+}
+
+// HelpModel returns the content of the help message as printed by PrintDefaults.
+func (df *DynFlags) HelpModel() *HelpModel {
+	return df.helpModel(df.VerboseHelp)
+}
+
+// helpModel builds the help message; verbose includes hidden groups and flags as well as deprecated names.
+func (df *DynFlags) helpModel(verbose bool) *HelpModel {
+	model := &HelpModel{
+		Title:       df.title,
+		Description: df.description,
+		Epilog:      df.epilog,
+		Pattern:     df.argPrefix() + df.keyPattern("<group>", "<identifier>", "<flag>"),
+		Width:       df.helpWidth(df.output),
+	}
+
+	// Sort group names
+	if df.SortGroups {
+		sort.Strings(df.groupOrder)
+	}
+
+	// Iterate over groups in the order they were added
+	for _, groupName := range df.groupOrder {
+		group := df.configGroups[groupName]
+		if !group.shown(verbose) {
+			continue
+		}
+		model.Groups = append(model.Groups, df.helpGroup(group, groupName, []string{groupName, "<IDENTIFIER>"}, verbose))
+	}
+	return model
+}
+
+// helpGroup builds the help of a single group, even if the group itself is hidden.
+// path holds the key parts leading to the flags of the group.
+func (df *DynFlags) helpGroup(group *ConfigGroup, groupPath string, path []string, verbose bool) HelpGroup {
+	help := HelpGroup{
+		Name:   groupPath,
+		Usage:  group.usage,
+		Hidden: group.hidden,
+		Flags:  df.collectHelpFlags(nil, group, groupPath, path, false, verbose),
+	}
+	df.collectHelpRules(&help, group, groupPath, verbose)
+	return help
+}
+
+// collectHelpRules adds the rules and identifier limits of a group and its shown child groups.
+func (df *DynFlags) collectHelpRules(help *HelpGroup, group *ConfigGroup, groupPath string, verbose bool) {
+	if group.minIdentifiers > 0 || group.maxIdentifiers > 0 {
+		help.Limits = append(help.Limits, HelpLimit{Group: groupPath, Min: group.minIdentifiers, Max: group.maxIdentifiers})
+	}
+	if group.global != nil {
+		for _, r := range group.global.rules {
+			help.Rules = append(help.Rules, HelpRule{Group: groupPath, Global: true, Kind: r.kind.String(), Flags: r.flags})
+		}
+	}
+	for _, r := range group.rules {
+		help.Rules = append(help.Rules, HelpRule{Group: groupPath, Kind: r.kind.String(), Flags: r.flags})
+	}
+
+	for _, childName := range group.groupOrder {
+		if child := group.groups[childName]; child.shown(verbose) {
+			df.collectHelpRules(help, child, df.keyPattern(groupPath, childName), verbose)
+		}
+	}
+}
+
+// helpPath returns the path of a group joined by the separator and the key parts leading to its flags.
+// Group-level flags are addressed by the path of their group without identifier.
+func (df *DynFlags) helpPath(group *ConfigGroup) (string, []string) {
+	if group.parent == nil {
+		return group.Name, []string{group.Name, "<IDENTIFIER>"}
+	}
+	parentPath, parentParts := df.helpPath(group.parent)
+	if group.isGlobal() {
+		return parentPath, parentParts[:len(parentParts)-1]
+	}
+	return df.keyPattern(parentPath, group.Name), slices.Concat(parentParts, []string{group.Name, "<IDENTIFIER>"})
+}
+
+// collectHelpFlags appends the group-level flags of a group, followed by its flags and the flags of its child groups.
+// path holds the key parts leading to the flags of the group; hidden marks flags of hidden child groups.
+func (df *DynFlags) collectHelpFlags(flags []HelpFlag, group *ConfigGroup, groupPath string, path []string, hidden, verbose bool) []HelpFlag {
+	// Group-level flags are listed before the flags of the identifiers
+	if group.global.hasIdentifierFlags(verbose) {
+		flags = df.appendHelpFlags(flags, group.global, groupPath, true, path[:len(path)-1], hidden, verbose)
+	}
+	flags = df.appendHelpFlags(flags, group, groupPath, false, path, hidden, verbose)
+
+	// Sort child group names
+	if df.SortGroups {
+		sort.Strings(group.groupOrder)
+	}
+
+	for _, childName := range group.groupOrder {
+		childPath := slices.Concat(path, []string{childName, "<IDENTIFIER>"})
+		child := group.groups[childName]
+		if !child.shown(verbose) {
+			continue
+		}
+		flags = df.collectHelpFlags(flags, child, df.keyPattern(groupPath, childName), childPath, hidden || child.hidden, verbose)
+	}
+	return flags
+}
+
+// appendHelpFlags appends one entry per flag of the group.
+func (df *DynFlags) appendHelpFlags(flags []HelpFlag, group *ConfigGroup, groupPath string, global bool, path []string, hidden, verbose bool) []HelpFlag {
+	// Sort flag names
+	if df.SortFlags {
+		sort.Strings(group.flagOrder)
+	}
+
+	prefix := df.argPrefix()
+	key := func(name string) string {
+		return prefix + df.keyPattern(slices.Concat(path, []string{name})...)
+	}
+
+	for _, flagName := range group.flagOrder {
+		flag := group.Flags[flagName]
+		if !flag.shown(verbose) {
+			continue
+		}
+
+		help := HelpFlag{
+			Name:       flagName,
+			Group:      groupPath,
+			Global:     global,
+			Key:        key(flagName),
+			MetaVar:    flag.metaVarOrType(),
+			Type:       flag.Type,
+			Default:    flag.Default,
+			Usage:      flag.Usage,
+			Range:      flag.rangeString(),
+			Min:        flag.min,
+			Max:        flag.max,
+			Deprecated: flag.deprecated,
+			Hidden:     hidden || flag.hidden,
+		}
+		for _, alias := range flag.aliases {
+			help.Aliases = append(help.Aliases, key(alias))
+		}
+		// Deprecated names are only listed in verbose help
+		for _, oldName := range group.aliasOrder {
+			if verbose && group.aliases[oldName] == flagName {
+				help.DeprecatedKeys = append(help.DeprecatedKeys, key(oldName))
+			}
+		}
+		flags = append(flags, help)
+	}
+	return flags
+}
@@ -0,0 +1,123 @@
+package dynflags_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/containeroo/dynflags"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHelp(t *testing.T) {
+	t.Parallel()
+
+	newFlags := func(behavior dynflags.ParseBehavior) (*dynflags.DynFlags, *bytes.Buffer) {
+		df := dynflags.New(behavior)
+		var output bytes.Buffer
+		df.SetOutput(&output)
+		df.Group("http").String("method", "GET", "HTTP method")
+		df.Group("tcp").String("address", "", "TCP address")
+		return df, &output
+	}
+
+	t.Run("Help flags print the full help", func(t *testing.T) {
+		t.Parallel()
+
+		for _, arg := range []string{"--help", "-h", "-help"} {
+			df, output := newFlags(dynflags.ContinueOnError)
+			err := df.Parse([]string{"--http.a.method", "POST", arg, "--tcp.a.address", "x"})
+			assert.ErrorIs(t, err, dynflags.ErrHelp)
+			assert.Contains(t, output.String(), "Usage: [--<group>.<identifier>.<flag> value]")
+			assert.Contains(t, output.String(), "--http.<IDENTIFIER>.method")
+			assert.Contains(t, output.String(), "--tcp.<IDENTIFIER>.address")
+			assert.Nil(t, df.Parsed().Lookup("tcp"), "parsing stops at the help flag")
+		}
+	})
+
+	t.Run("Group help prints the group only", func(t *testing.T) {
+		t.Parallel()
+
+		df, output := newFlags(dynflags.ContinueOnError)
+		err := df.Parse([]string{"--http.help"})
+		assert.ErrorIs(t, err, dynflags.ErrHelp)
+		assert.Contains(t, output.String(), "Usage: [--http.<identifier>.<flag> value]")
+		assert.Contains(t, output.String(), "--http.<IDENTIFIER>.method")
+		assert.NotContains(t, output.String(), "tcp")
+	})
+
+	t.Run("Group help of an unknown group is an unknown flag", func(t *testing.T) {
+		t.Parallel()
+
+		df, output := newFlags(dynflags.ContinueOnError)
+		err := df.Parse([]string{"--udp.help"})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"--udp.help"}, df.UnknownArgs())
+		assert.Empty(t, output.String())
+	})
+
+	t.Run("Group-level flag named help takes precedence", func(t *testing.T) {
+		t.Parallel()
+
+		df, output := newFlags(dynflags.ContinueOnError)
+		df.Group("http").Global().String("help", "", "Help URL")
+		err := df.Parse([]string{"--http.help", "https://example.com"})
+		assert.NoError(t, err)
+		assert.Equal(t, "https://example.com", df.Parsed().Lookup("http").Global().Lookup("help"))
+		assert.Empty(t, output.String())
+	})
+
+	t.Run("Group help follows the prefix style", func(t *testing.T) {
+		t.Parallel()
+
+		df, output := newFlags(dynflags.ContinueOnError)
+		df.SetPrefixStyle(dynflags.PrefixNone)
+		assert.ErrorIs(t, df.Parse([]string{"http.help"}), dynflags.ErrHelp)
+		assert.Contains(t, output.String(), "Usage: [http.<identifier>.<flag> value]")
+		assert.ErrorIs(t, df.Parse([]string{"--help"}), dynflags.ErrHelp)
+
+		df, output = newFlags(dynflags.ContinueOnError)
+		assert.NoError(t, df.Parse([]string{"-http.help"}))
+		assert.Equal(t, []string{"-http.help"}, df.UnknownArgs())
+		assert.Empty(t, output.String())
+	})
+
+	t.Run("Custom help flags", func(t *testing.T) {
+		t.Parallel()
+
+		df, _ := newFlags(dynflags.ContinueOnError)
+		df.SetHelpFlags("usage")
+		assert.ErrorIs(t, df.Parse([]string{"--usage"}), dynflags.ErrHelp)
+		assert.NoError(t, df.Parse([]string{"--help"}))
+		assert.ErrorIs(t, df.Parse([]string{"--http.usage"}), dynflags.ErrHelp)
+	})
+
+	t.Run("Help flags can be disabled", func(t *testing.T) {
+		t.Parallel()
+
+		df, _ := newFlags(dynflags.ContinueOnError)
+		df.SetHelpFlags()
+		assert.NoError(t, df.Parse([]string{"--help"}))
+		assert.Equal(t, []string{"--help"}, df.UnknownArgs())
+	})
+
+	t.Run("Custom usage function", func(t *testing.T) {
+		t.Parallel()
+
+		df, output := newFlags(dynflags.ContinueOnError)
+		df.SetUsage(func() { output.WriteString("custom usage") })
+		assert.ErrorIs(t, df.Parse([]string{"--help"}), dynflags.ErrHelp)
+		assert.Equal(t, "custom usage", output.String())
+	})
+
+	t.Run("ExitOnError exits with status 0", func(t *testing.T) {
+		t.Parallel()
+
+		df, output := newFlags(dynflags.ExitOnError)
+		code := -1
+		df.SetExitFunc(func(c int) { code = c })
+		err := df.Parse([]string{"--help"})
+		assert.ErrorIs(t, err, dynflags.ErrHelp)
+		assert.Equal(t, 0, code)
+		assert.NotContains(t, output.String(), "help requested")
+	})
+}
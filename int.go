@@ -38,8 +38,7 @@ func (g *ConfigGroup) Int(name string, value int, usage string) *Flag {
 		Usage:   usage,
 		value:   &IntValue{Bound: bound},
 	}
-	g.Flags[name] = flag
-	g.flagOrder = append(g.flagOrder, name)
+	g.addFlag(name, flag)
 	return flag
 }
 
@@ -47,8 +47,7 @@ func (g *ConfigGroup) IntSlices(name string, value []int, usage string) *Flag {
 		Usage:   usage,
 		value:   &IntSlicesValue{Bound: bound},
 	}
-	g.Flags[name] = flag
-	g.flagOrder = append(g.flagOrder, name)
+	g.addFlag(name, flag)
 	return flag
 }
 
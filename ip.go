@@ -49,8 +49,7 @@ func (g *ConfigGroup) IP(name, value, usage string) *Flag {
 		Usage:   usage,
 		value:   &IPValue{Bound: *bound},
 	}
-	g.Flags[name] = flag
-	g.flagOrder = append(g.flagOrder, name)
+	g.addFlag(name, flag)
 	return flag
 }
 
@@ -48,8 +48,7 @@ func (g *ConfigGroup) IPSlices(name string, value []net.IP, usage string) *Flag
 		Usage:   usage,
 		value:   &IPSlicesValue{Bound: bound},
 	}
-	g.Flags[name] = flag
-	g.flagOrder = append(g.flagOrder, name)
+	g.addFlag(name, flag)
 	return flag
 }
 
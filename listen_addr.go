@@ -47,8 +47,7 @@ func (g *ConfigGroup) ListenAddr(name, defaultValue, usage string) *Flag {
 		Usage:   usage,
 		value:   &ListenAddrValue{Bound: bound},
 	}
-	g.Flags[name] = flag
-	g.flagOrder = append(g.flagOrder, name)
+	g.addFlag(name, flag)
 	return flag
 }
 
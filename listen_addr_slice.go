@@ -51,8 +51,7 @@ func (g *ConfigGroup) ListenAddrSlices(name string, value []string, usage string
 		Usage:   usage,
 		value:   &ListenAddrSlicesValue{Bound: bound},
 	}
-	g.Flags[name] = flag
-	g.flagOrder = append(g.flagOrder, name)
+	g.addFlag(name, flag)
 	return flag
 }
 
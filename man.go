@@ -0,0 +1,137 @@
+package dynflags
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// ManHeader describes the header of a standalone man page.
+type ManHeader struct {
+	Name    string    // Name of the program; required
+	Section string    // Manual section; defaults to "1"
+	Date    time.Time // Date of the page; omitted if zero
+	Source  string    // Source of the program, e.g. its name and version
+	Manual  string    // Title of the manual
+}
+
+// GenMan writes a standalone man page in roff format to w, with the title, description,
+// one section per group and the epilog. Hidden groups and flags are left out.
+// It returns an error if the header or its name is missing.
+func (df *DynFlags) GenMan(w io.Writer, header *ManHeader) error {
+	if header == nil || header.Name == "" {
+		return errors.New("man page header requires a name")
+	}
+	section := header.Section
+	if section == "" {
+		section = "1"
+	}
+	var date string
+	if !header.Date.IsZero() {
+		date = header.Date.Format("Jan 2006")
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, ".TH %s %s %s %s %s\n", // nolint:errcheck
+		roffQuote(strings.ToUpper(header.Name)), roffQuote(section), roffQuote(date), roffQuote(header.Source), roffQuote(header.Manual))
+
+	b.WriteString(".SH NAME\n")
+	name := roffEscape(header.Name)
+	if df.title != "" {
+		name += ` \- ` + roffEscape(df.title)
+	}
+	b.WriteString(name + "\n")
+
+	b.WriteString(".SH SYNOPSIS\n")
+	fmt.Fprintf(&b, "\\fB%s\\fP [\\fB%s\\fP \\fIvalue\\fP]...\n", // nolint:errcheck
+		roffEscape(header.Name), roffEscape(df.argPrefix()+df.keyPattern("<group>", "<identifier>", "<flag>")))
+
+	if df.description != "" {
+		b.WriteString(".SH DESCRIPTION\n")
+		b.WriteString(roffText(df.description))
+	}
+
+	df.writeManSections(&b)
+
+	if df.epilog != "" {
+		b.WriteString(".SH NOTES\n")
+		b.WriteString(roffText(df.epilog))
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// GenManSections writes one roff section per group to w, to be embedded into an existing man page.
+// Hidden groups and flags are left out.
+func (df *DynFlags) GenManSections(w io.Writer) error {
+	var b strings.Builder
+	df.writeManSections(&b)
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// writeManSections writes the section of each group to b.
+func (df *DynFlags) writeManSections(b *strings.Builder) {
+	for _, group := range df.helpModel(true).Groups {
+		if group.Hidden {
+			continue
+		}
+
+		heading := group.Usage
+		if heading == "" {
+			heading = group.Name
+		}
+		fmt.Fprintf(b, ".SH %s\n", roffQuote(strings.ToUpper(heading))) // nolint:errcheck
+
+		for _, flag := range group.Flags {
+			if flag.Hidden {
+				continue
+			}
+			writeManFlag(b, append([]string{flag.Key}, flag.Aliases...), flag.MetaVar, flagUsage(flag))
+			for _, oldKey := range flag.DeprecatedKeys {
+				writeManFlag(b, []string{oldKey}, flag.MetaVar, fmt.Sprintf("Deprecated, use %s instead", flag.Key))
+			}
+		}
+	}
+}
+
+// writeManFlag writes a tagged paragraph of a flag to b.
+func writeManFlag(b *strings.Builder, keys []string, metaVar, usage string) {
+	tags := make([]string, 0, len(keys))
+	for _, key := range keys {
+		tags = append(tags, `\fB`+roffEscape(key)+`\fP`)
+	}
+	b.WriteString(".TP\n")
+	fmt.Fprintf(b, "%s \\fI%s\\fP\n", strings.Join(tags, ", "), roffEscape(metaVar)) // nolint:errcheck
+	b.WriteString(roffText(usage))
+}
+
+// roffText escapes text as paragraphs; every line ends with a newline.
+func roffText(text string) string {
+	var b strings.Builder
+	for _, line := range strings.Split(strings.TrimRight(text, "\n"), "\n") {
+		if line == "" {
+			b.WriteString(".PP\n")
+			continue
+		}
+		b.WriteString(roffEscape(line) + "\n")
+	}
+	return b.String()
+}
+
+// roffEscape escapes backslashes and dashes, and prevents text from being taken for a request.
+func roffEscape(text string) string {
+	text = strings.NewReplacer(`\`, `\e`, "-", `\-`).Replace(text)
+	if strings.HasPrefix(text, ".") || strings.HasPrefix(text, "'") {
+		text = `\&` + text
+	}
+	return text
+}
+
+// roffQuote escapes text as a quoted argument of a request.
+func roffQuote(text string) string {
+	return `"` + strings.ReplaceAll(roffEscape(text), `"`, `\(dq`) + `"`
+}
@@ -0,0 +1,102 @@
+package dynflags_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/containeroo/dynflags"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenMan(t *testing.T) {
+	t.Parallel()
+
+	newFlags := func() *dynflags.DynFlags {
+		df := dynflags.New(dynflags.ContinueOnError)
+		df.Title("Endpoint checker")
+		df.Description("Checks endpoints.\n\nRuns until stopped.")
+		df.Epilog("See https://example.com")
+		http := df.Group("http")
+		http.Usage("HTTP flags")
+		http.String("method", "GET", "HTTP method").Alias("m")
+		http.Alias("verb", "method")
+		http.String("secret", "", "Secret").Hidden()
+		df.Group("debug").Hidden()
+		return df
+	}
+
+	t.Run("Standalone page", func(t *testing.T) {
+		t.Parallel()
+
+		df := newFlags()
+		var buf bytes.Buffer
+		err := df.GenMan(&buf, &dynflags.ManHeader{
+			Name:   "checker",
+			Date:   time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC),
+			Source: "checker 1.0",
+			Manual: "User Commands",
+		})
+		assert.NoError(t, err)
+
+		expected := ".TH \"CHECKER\" \"1\" \"Mar 2024\" \"checker 1.0\" \"User Commands\"\n" +
+			".SH NAME\n" +
+			"checker \\- Endpoint checker\n" +
+			".SH SYNOPSIS\n" +
+			"\\fBchecker\\fP [\\fB\\-\\-<group>.<identifier>.<flag>\\fP \\fIvalue\\fP]...\n" +
+			".SH DESCRIPTION\n" +
+			"Checks endpoints.\n" +
+			".PP\n" +
+			"Runs until stopped.\n" +
+			".SH \"HTTP FLAGS\"\n" +
+			".TP\n" +
+			"\\fB\\-\\-http.<IDENTIFIER>.method\\fP, \\fB\\-\\-http.<IDENTIFIER>.m\\fP \\fISTRING\\fP\n" +
+			"HTTP method (default: GET)\n" +
+			".TP\n" +
+			"\\fB\\-\\-http.<IDENTIFIER>.verb\\fP \\fISTRING\\fP\n" +
+			"Deprecated, use \\-\\-http.<IDENTIFIER>.method instead\n" +
+			".SH NOTES\n" +
+			"See https://example.com\n"
+		assert.Equal(t, expected, buf.String())
+	})
+
+	t.Run("Header without name", func(t *testing.T) {
+		t.Parallel()
+
+		df := dynflags.New(dynflags.ContinueOnError)
+		df.Group("http").String("method", "GET", "HTTP method")
+
+		var buf bytes.Buffer
+		assert.EqualError(t, df.GenMan(&buf, nil), "man page header requires a name")
+		assert.EqualError(t, df.GenMan(&buf, &dynflags.ManHeader{Section: "8"}), "man page header requires a name")
+		assert.Empty(t, buf.String())
+	})
+
+	t.Run("Embeddable sections", func(t *testing.T) {
+		t.Parallel()
+
+		df := newFlags()
+		var buf bytes.Buffer
+		err := df.GenManSections(&buf)
+		assert.NoError(t, err)
+
+		output := buf.String()
+		assert.True(t, strings.HasPrefix(output, ".SH \"HTTP FLAGS\"\n.TP\n"), output)
+		assert.NotContains(t, output, ".TH")
+		assert.NotContains(t, output, "NOTES")
+		assert.NotContains(t, output, "secret")
+		assert.NotContains(t, output, "DEBUG")
+	})
+
+	t.Run("Text is escaped", func(t *testing.T) {
+		t.Parallel()
+
+		df := dynflags.New(dynflags.ContinueOnError)
+		df.Group("path").String("root", `C:\data`, ".hidden files are skipped")
+
+		var buf bytes.Buffer
+		assert.NoError(t, df.GenManSections(&buf))
+		assert.Contains(t, buf.String(), "\n\\&.hidden files are skipped (default: C:\\edata)\n")
+	})
+}
@@ -0,0 +1,157 @@
+package dynflags
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+)
+
+// GenMarkdown writes the documentation of all groups and flags as Markdown to w,
+// with one table per group. Like PrintDefaults, hidden groups and flags as well as
+// deprecated flags and names are only listed if VerboseHelp is set.
+func (df *DynFlags) GenMarkdown(w io.Writer) error {
+	model := df.HelpModel()
+	var b strings.Builder
+
+	if model.Title != "" {
+		fmt.Fprintf(&b, "# %s\n\n", model.Title) // nolint:errcheck
+	}
+	if model.Description != "" {
+		fmt.Fprintf(&b, "%s\n\n", model.Description) // nolint:errcheck
+	}
+
+	for _, group := range model.Groups {
+		heading := group.Usage
+		if heading == "" {
+			heading = group.Name
+		}
+		if group.Hidden {
+			heading += " (hidden)"
+		}
+		fmt.Fprintf(&b, "## %s\n\n", heading) // nolint:errcheck
+
+		for _, limit := range group.Limits {
+			fmt.Fprintf(&b, "%s\n\n", markdownLimit(limit)) // nolint:errcheck
+		}
+
+		var rows []string
+		for _, flag := range group.Flags {
+			constraints := markdownConstraints(flag, group.Rules)
+			rows = append(rows, markdownRow(flag, flag.Key, flag.Aliases, constraints, markdownDeprecation(flag.Deprecated)))
+			for _, oldKey := range flag.DeprecatedKeys {
+				rows = append(rows, markdownRow(flag, oldKey, nil, constraints, fmt.Sprintf("Renamed to `%s`", flag.Key)))
+			}
+		}
+		if len(rows) == 0 {
+			continue
+		}
+
+		b.WriteString("| Flag | Type | Default | Usage | Constraints | Deprecation |\n")
+		b.WriteString("| --- | --- | --- | --- | --- | --- |\n")
+		for _, row := range rows {
+			b.WriteString(row)
+		}
+		b.WriteString("\n")
+	}
+
+	if model.Epilog != "" {
+		fmt.Fprintf(&b, "%s\n", model.Epilog) // nolint:errcheck
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// markdownRow returns the table row of a flag listed under the given key.
+func markdownRow(flag HelpFlag, key string, aliases []string, constraints, deprecation string) string {
+	keys := make([]string, 0, len(aliases)+1)
+	for _, k := range append([]string{key}, aliases...) {
+		keys = append(keys, markdownCode(k))
+	}
+
+	var defaultValue string
+	if flag.Default != nil && flag.Default != "" {
+		defaultValue = markdownCode(fmt.Sprint(flag.Default))
+	}
+
+	cells := []string{
+		strings.Join(keys, ", "),
+		markdownCode(string(flag.Type)),
+		defaultValue,
+		markdownUsage(flag),
+		constraints,
+		deprecation,
+	}
+	return "| " + strings.Join(cells, " | ") + " |\n"
+}
+
+// markdownUsage returns the usage cell of a flag, marking flags only listed with VerboseHelp.
+func markdownUsage(flag HelpFlag) string {
+	if flag.Hidden {
+		return markdownEscape(flag.Usage) + " (hidden)"
+	}
+	return markdownEscape(flag.Usage)
+}
+
+// markdownConstraints returns the constraints cell of a flag: its range and the rules it is part of.
+func markdownConstraints(flag HelpFlag, rules []HelpRule) string {
+	var constraints []string
+	if flag.Range != "" {
+		constraints = append(constraints, markdownCode(flag.Range))
+	}
+
+	for _, rule := range rules {
+		if rule.Group != flag.Group || rule.Global != flag.Global || !slices.Contains(rule.Flags, flag.Name) {
+			continue
+		}
+		others := make([]string, 0, len(rule.Flags)-1)
+		for _, name := range rule.Flags {
+			if name != flag.Name {
+				others = append(others, markdownCode(name))
+			}
+		}
+		switch {
+		case rule.Kind == ruleMutuallyExclusive.String():
+			constraints = append(constraints, "Mutually exclusive with "+strings.Join(others, ", "))
+		case rule.Kind == ruleRequiredTogether.String():
+			constraints = append(constraints, "Requires "+strings.Join(others, ", "))
+		case len(others) == 0:
+			constraints = append(constraints, "Required")
+		default:
+			constraints = append(constraints, "Required unless "+strings.Join(others, " or ")+" is set")
+		}
+	}
+	return strings.Join(constraints, "; ")
+}
+
+// markdownLimit returns the sentence describing the identifier limit of a group.
+func markdownLimit(limit HelpLimit) string {
+	group := markdownCode(limit.Group)
+	switch {
+	case limit.Max == 0:
+		return fmt.Sprintf("Group %s requires at least %s.", group, pluralize(limit.Min, "identifier"))
+	case limit.Min == 0:
+		return fmt.Sprintf("Group %s allows at most %s.", group, pluralize(limit.Max, "identifier"))
+	default:
+		return fmt.Sprintf("Group %s requires %d to %d identifiers.", group, limit.Min, limit.Max)
+	}
+}
+
+// markdownDeprecation returns the deprecation cell of a flag.
+func markdownDeprecation(msg string) string {
+	if msg == "" {
+		return ""
+	}
+	return "Deprecated: " + markdownEscape(msg)
+}
+
+// markdownCode formats text as inline code within a table cell.
+func markdownCode(text string) string {
+	return "`" + markdownEscape(text) + "`"
+}
+
+// markdownEscape escapes text for a table cell.
+func markdownEscape(text string) string {
+	return strings.NewReplacer("|", `\|`, "\n", "<br>").Replace(text)
+}
@@ -0,0 +1,142 @@
+package dynflags_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/containeroo/dynflags"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenMarkdown(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Tables per group", func(t *testing.T) {
+		t.Parallel()
+
+		df := dynflags.New(dynflags.ContinueOnError)
+		df.Title("My App")
+		df.Description("Checks endpoints.")
+		df.Epilog("See https://example.com")
+		http := df.Group("http")
+		http.Usage("HTTP flags")
+		http.Global().Int("concurrency", 1, "Concurrency")
+		http.Duration("timeout", time.Second, "Timeout").Range(time.Second, time.Minute).Alias("t")
+		http.String("verb", "", "Verb | method").Deprecated("use method")
+		http.String("method", "GET", "HTTP method")
+		http.Alias("request-method", "method")
+		http.String("secret", "", "Secret").Hidden()
+		df.Group("tcp")
+		debug := df.Group("debug")
+		debug.Hidden()
+		debug.Bool("pprof", false, "Enable pprof")
+
+		var buf bytes.Buffer
+		err := df.GenMarkdown(&buf)
+		assert.NoError(t, err)
+
+		expected := "# My App\n\n" +
+			"Checks endpoints.\n\n" +
+			"## HTTP flags\n\n" +
+			"| Flag | Type | Default | Usage | Constraints | Deprecation |\n" +
+			"| --- | --- | --- | --- | --- | --- |\n" +
+			"| `--http.concurrency` | `INT` | `1` | Concurrency |  |  |\n" +
+			"| `--http.<IDENTIFIER>.timeout`, `--http.<IDENTIFIER>.t` | `DURATION` | `1s` | Timeout | `[1s..1m]` |  |\n" +
+			"| `--http.<IDENTIFIER>.method` | `STRING` | `GET` | HTTP method |  |  |\n" +
+			"\n" +
+			"## tcp\n\n" +
+			"See https://example.com\n"
+		assert.Equal(t, expected, buf.String())
+	})
+
+	t.Run("Verbose help lists hidden and deprecated flags", func(t *testing.T) {
+		t.Parallel()
+
+		df := dynflags.New(dynflags.ContinueOnError)
+		df.VerboseHelp = true
+		http := df.Group("http")
+		http.String("verb", "", "Verb | method").Deprecated("use method")
+		http.String("method", "GET", "HTTP method")
+		http.Alias("request-method", "method")
+		http.String("secret", "", "Secret").Hidden()
+		debug := df.Group("debug")
+		debug.Hidden()
+		debug.Bool("pprof", false, "Enable pprof")
+
+		var buf bytes.Buffer
+		assert.NoError(t, df.GenMarkdown(&buf))
+
+		expected := "## http\n\n" +
+			"| Flag | Type | Default | Usage | Constraints | Deprecation |\n" +
+			"| --- | --- | --- | --- | --- | --- |\n" +
+			"| `--http.<IDENTIFIER>.verb` | `STRING` |  | Verb \\| method |  | Deprecated: use method |\n" +
+			"| `--http.<IDENTIFIER>.method` | `STRING` | `GET` | HTTP method |  |  |\n" +
+			"| `--http.<IDENTIFIER>.request-method` | `STRING` | `GET` | HTTP method |  | Renamed to `--http.<IDENTIFIER>.method` |\n" +
+			"| `--http.<IDENTIFIER>.secret` | `STRING` |  | Secret (hidden) |  |  |\n" +
+			"\n" +
+			"## debug (hidden)\n\n" +
+			"| Flag | Type | Default | Usage | Constraints | Deprecation |\n" +
+			"| --- | --- | --- | --- | --- | --- |\n" +
+			"| `--debug.<IDENTIFIER>.pprof` | `BOOL` | `false` | Enable pprof |  |  |\n" +
+			"\n"
+		assert.Equal(t, expected, buf.String())
+	})
+
+	t.Run("Rules and identifier limits", func(t *testing.T) {
+		t.Parallel()
+
+		df := dynflags.New(dynflags.ContinueOnError)
+		http := df.Group("http")
+		http.Int("port", 80, "Port").Range(1, 65535)
+		http.String("body", "", "Request body")
+		http.String("body-file", "", "File with the request body")
+		http.String("address", "", "Target address")
+		http.String("url", "", "Target URL")
+		http.MutuallyExclusive("body", "body-file")
+		http.RequiredTogether("port", "address")
+		http.OneRequired("address", "url")
+		http.MinIdentifiers(1)
+		http.MaxIdentifiers(3)
+		db := df.Group("db")
+		db.Global().String("dsn", "", "Database DSN")
+		db.Global().OneRequired("dsn")
+		db.MaxIdentifiers(1)
+
+		var buf bytes.Buffer
+		assert.NoError(t, df.GenMarkdown(&buf))
+
+		expected := "## http\n\n" +
+			"Group `http` requires 1 to 3 identifiers.\n\n" +
+			"| Flag | Type | Default | Usage | Constraints | Deprecation |\n" +
+			"| --- | --- | --- | --- | --- | --- |\n" +
+			"| `--http.<IDENTIFIER>.port` | `INT` | `80` | Port | `[1..65535]`; Requires `address` |  |\n" +
+			"| `--http.<IDENTIFIER>.body` | `STRING` |  | Request body | Mutually exclusive with `body-file` |  |\n" +
+			"| `--http.<IDENTIFIER>.body-file` | `STRING` |  | File with the request body | Mutually exclusive with `body` |  |\n" +
+			"| `--http.<IDENTIFIER>.address` | `STRING` |  | Target address | Requires `port`; Required unless `url` is set |  |\n" +
+			"| `--http.<IDENTIFIER>.url` | `STRING` |  | Target URL | Required unless `address` is set |  |\n" +
+			"\n" +
+			"## db\n\n" +
+			"Group `db` allows at most 1 identifier.\n\n" +
+			"| Flag | Type | Default | Usage | Constraints | Deprecation |\n" +
+			"| --- | --- | --- | --- | --- | --- |\n" +
+			"| `--db.dsn` | `STRING` |  | Database DSN | Required |  |\n" +
+			"\n"
+		assert.Equal(t, expected, buf.String())
+	})
+
+	t.Run("Hidden child groups are left out", func(t *testing.T) {
+		t.Parallel()
+
+		df := dynflags.New(dynflags.ContinueOnError)
+		cluster := df.Group("cluster")
+		cluster.Group("node").String("address", "", "Node address")
+		cluster.Group("internal").Hidden()
+		cluster.Sub("internal").String("trace", "", "Trace ID")
+
+		var buf bytes.Buffer
+		assert.NoError(t, df.GenMarkdown(&buf))
+		assert.Contains(t, buf.String(), "`--cluster.<IDENTIFIER>.node.<IDENTIFIER>.address`")
+		assert.NotContains(t, buf.String(), "trace")
+	})
+}
@@ -1,20 +1,53 @@
 package dynflags
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 )
 
 // Parse parses the CLI arguments and populates parsed and unknown groups.
+// After all arguments are processed, the parsed groups are validated against the rules
+// of their groups; violations are returned as *ValidationError.
+// With ExitOnError, the error and usage are printed and the program exits with status 2;
+// with PanicOnError, Parse panics with the error.
+// If help is requested, it is printed and ErrHelp is returned; ExitOnError exits with status 0.
 func (df *DynFlags) Parse(args []string) error {
+	err := df.parse(args)
+	if err == nil {
+		return nil
+	}
+
+	switch df.parseBehavior {
+	case ExitOnError:
+		if errors.Is(err, ErrHelp) {
+			df.exit(0)
+			return err
+		}
+		fmt.Fprintln(df.output, err) // nolint:errcheck
+		df.usage()
+		df.exit(2)
+	case PanicOnError:
+		panic(err)
+	}
+	return err
+}
+
+// parse processes the arguments. Unless ContinueOnError is set, it stops at the first invalid argument.
+func (df *DynFlags) parse(args []string) error {
 	for i := 0; i < len(args); i++ {
 		arg := args[i]
 
+		// Help stops parsing regardless of the parse behavior
+		if df.handleHelp(arg) {
+			return ErrHelp
+		}
+
 		// Extract the key and value
 		fullKey, value, err := df.extractKeyValue(arg, args, &i)
 		if err != nil {
 			// Handle unparseable arguments
-			if df.parseBehavior == ExitOnError {
+			if df.parseBehavior != ContinueOnError {
 				return err
 			}
 			df.unparsedArgs = append(df.unparsedArgs, arg)
@@ -22,10 +55,10 @@ func (df *DynFlags) Parse(args []string) error {
 		}
 
 		// Validate and split the key
-		parentName, identifier, flagName, err := df.splitKey(fullKey)
+		path, err := df.splitKey(fullKey)
 		if err != nil {
 			// Handle invalid keys
-			if df.parseBehavior == ExitOnError {
+			if df.parseBehavior != ContinueOnError {
 				return err
 			}
 			df.unparsedArgs = append(df.unparsedArgs, arg)
@@ -33,73 +66,234 @@ func (df *DynFlags) Parse(args []string) error {
 		}
 
 		// Handle the flag
-		if err := df.handleFlag(parentName, identifier, flagName, value); err != nil {
-			if df.parseBehavior == ExitOnError {
+		if err := df.handleFlag(path, value); err != nil {
+			if df.parseBehavior != ContinueOnError {
 				return err
 			}
 			df.unparsedArgs = append(df.unparsedArgs, arg)
 		}
 	}
-	return nil
+
+	if df.wildcard != "" {
+		df.applyWildcards(df.parsedGroups)
+	}
+
+	// Rules are checked on the final values, regardless of the parse behavior
+	return df.validate()
 }
 
 // extractKeyValue extracts the key and value from an argument.
 func (df *DynFlags) extractKeyValue(arg string, args []string, index *int) (key, value string, err error) {
-	if !strings.HasPrefix(arg, "--") {
+	key, ok := df.trimPrefix(arg)
+	if !ok || key == "" {
 		// Invalid argument format
 		return "", "", fmt.Errorf("invalid argument format: %s", arg)
 	}
 
-	arg = strings.TrimPrefix(arg, "--")
-
 	// Handle "--key=value" format
-	if strings.Contains(arg, "=") {
-		parts := strings.SplitN(arg, "=", 2)
-		return parts[0], parts[1], nil
+	if k, v, found := strings.Cut(key, "="); found {
+		return k, v, nil
 	}
 
-	// Handle "--key value" format
-	if *index+1 < len(args) && !strings.HasPrefix(args[*index+1], "--") {
+	// Handle "--key value" format. Keys without prefix must use "key=value",
+	// otherwise any positional argument would be taken for a value.
+	if df.prefixStyle != PrefixNone && *index+1 < len(args) && !df.looksLikeFlag(args[*index+1]) {
 		*index++
-		return arg, args[*index], nil
+		return key, args[*index], nil
 	}
 
 	// Missing value for the key
-	return "", "", fmt.Errorf("missing value for flag: --%s", arg)
+	return "", "", fmt.Errorf("missing value for flag: %s", arg)
+}
+
+// trimPrefix removes the configured prefix from an argument.
+// It reports false if the argument does not carry the expected prefix.
+func (df *DynFlags) trimPrefix(arg string) (string, bool) {
+	switch df.prefixStyle {
+	case PrefixSingleDash:
+		if strings.HasPrefix(arg, "--") {
+			return "", false
+		}
+		return strings.CutPrefix(arg, "-")
+	case PrefixAnyDash:
+		if key, ok := strings.CutPrefix(arg, "--"); ok {
+			return key, true
+		}
+		return strings.CutPrefix(arg, "-")
+	case PrefixNone:
+		if strings.HasPrefix(arg, "-") {
+			return "", false
+		}
+		return arg, true
+	default:
+		return strings.CutPrefix(arg, "--")
+	}
+}
+
+// looksLikeFlag reports whether the argument is a flag rather than the value of the previous flag.
+func (df *DynFlags) looksLikeFlag(arg string) bool {
+	// Negative numbers are values, even if single dashes are accepted
+	if len(arg) > 1 && arg[0] == '-' && arg[1] >= '0' && arg[1] <= '9' {
+		return false
+	}
+	_, ok := df.trimPrefix(arg)
+	return ok
 }
 
 // splitKey validates and splits a key into its components.
-func (df *DynFlags) splitKey(fullKey string) (group, identifier, flag string, err error) {
-	parts := strings.Split(fullKey, ".")
-	if len(parts) != 3 {
-		return "", "", "", fmt.Errorf("flag must follow the pattern: --<group>.<identifier>.<flag>")
+// The key alternates between group and identifier and ends with the flag name,
+// e.g. "group.identifier.flag" or "group.identifier.child.identifier.flag".
+// Group-level flags omit the last identifier, e.g. "group.flag".
+func (df *DynFlags) splitKey(fullKey string) ([]string, error) {
+	parts, err := splitPath(fullKey, df.separator)
+	if err != nil {
+		return nil, err
+	}
+	if len(parts) < 2 {
+		return nil, fmt.Errorf("flag must follow the pattern: %s%s", df.argPrefix(), df.keyPattern("<group>", "<identifier>", "<flag>"))
+	}
+	return parts, nil
+}
+
+// splitPath splits a key by the separator. A part enclosed in brackets
+// is taken literally, and a backslash escapes the following character.
+func splitPath(key, sep string) ([]string, error) {
+	var parts []string
+	var part strings.Builder
+
+	for i := 0; i <= len(key); {
+		// Bracket-quoted part, e.g. "[api.example.com]"
+		if part.Len() == 0 && i < len(key) && key[i] == '[' {
+			end := strings.IndexByte(key[i:], ']')
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated '[' in key: %s", key)
+			}
+			part.WriteString(key[i+1 : i+end])
+			i += end + 1
+			if i < len(key) && !strings.HasPrefix(key[i:], sep) {
+				return nil, fmt.Errorf("unexpected character after ']' in key: %s", key)
+			}
+			if part.Len() == 0 {
+				return nil, fmt.Errorf("empty part in key: %s", key)
+			}
+			continue
+		}
+
+		switch {
+		case i == len(key):
+			parts = append(parts, part.String())
+			return parts, nil
+		case strings.HasPrefix(key[i:], sep):
+			parts = append(parts, part.String())
+			part.Reset()
+			i += len(sep)
+		case key[i] == '\\' && i+1 < len(key):
+			part.WriteByte(key[i+1])
+			i += 2
+		default:
+			part.WriteByte(key[i])
+			i++
+		}
 	}
-	return parts[0], parts[1], parts[2], nil
+	return parts, nil
 }
 
 // handleFlag processes a known or unknown flag.
-func (df *DynFlags) handleFlag(parentName, identifier, flagName, value string) error {
-	if parentGroup, exists := df.configGroups[parentName]; exists {
-		if flag := parentGroup.Lookup(flagName); flag != nil {
-			// Known flag
-			parsedGroup := df.createOrGetParsedGroup(parentGroup, identifier)
-			return df.setFlagValue(parsedGroup, flagName, flag, value)
+func (df *DynFlags) handleFlag(path []string, value string) error {
+	groupName, groupWarning := df.resolveGroup(path[0])
+	path[0] = groupName
+	groupPath, flagName := path[0], path[len(path)-1]
+	// Group-level flags have no identifier for the innermost group
+	global := len(path)%2 == 0
+
+	// Walk down the child groups; the path alternates between group and identifier.
+	// The group path keeps the parent identifiers, e.g. "cluster.eu.node".
+	group := df.configGroups[groupPath]
+	var groupSuggestion string
+	if group == nil {
+		groupSuggestion = suggest(groupPath, df.groupNames())
+	}
+	for i := 2; group != nil && i < len(path)-1; i += 2 {
+		parentPath := df.keyPattern(groupPath, path[i-1])
+		groupPath = df.keyPattern(parentPath, path[i])
+		child := group.Sub(path[i])
+		if child == nil {
+			if similar := suggest(path[i], group.groupNames()); similar != "" {
+				groupSuggestion = df.keyPattern(parentPath, similar)
+			}
+		}
+		group = child
+	}
+	if global && group != nil {
+		group = group.global
+	}
+
+	name, flag, warning := group.resolveFlag(groupPath, flagName)
+	if flag == nil {
+		// Unknown flag
+		return &UnknownFlagError{
+			Group:           groupPath,
+			Flag:            flagName,
+			Suggestion:      suggest(flagName, group.flagNames()),
+			GroupSuggestion: groupSuggestion,
 		}
 	}
+	for _, msg := range []string{groupWarning, warning} {
+		if msg != "" {
+			df.warning(msg)
+		}
+	}
+	flagName = name
 
-	// Unknown flag
-	return fmt.Errorf("unknown flag '%s' in group '%s'", flagName, parentName)
+	// Known flag
+	groups, globals := df.parsedGroups, df.parsedGlobals
+	config := df.configGroups[path[0]]
+	var parsedGroup *ParsedGroup
+	for i := 0; i < len(path)-2; i += 2 {
+		if i > 0 {
+			config = config.Sub(path[i])
+			groups, globals = parsedGroup.childMaps()
+		}
+		parsedGroup = df.createOrGetParsedGroup(groups, config, path[i+1])
+	}
+	if global {
+		if parsedGroup != nil {
+			config = config.Sub(path[len(path)-2])
+			_, globals = parsedGroup.childMaps()
+		}
+		parsedGroup = df.createOrGetGlobalGroup(globals, config)
+	}
+
+	if err := df.setFlagValue(parsedGroup, flagName, flag, value); err != nil {
+		identifier := path[len(path)-2]
+		if global {
+			identifier = ""
+		}
+		return &FlagError{Group: groupPath, Identifier: identifier, Flag: flagName, Value: value, Err: err}
+	}
+	return nil
 }
 
 // setFlagValue sets the value of a known flag in the parsed group.
 func (df *DynFlags) setFlagValue(parsedGroup *ParsedGroup, flagName string, flag *Flag, value string) error {
 	parsedValue, err := flag.value.Parse(value)
 	if err != nil {
-		return fmt.Errorf("failed to parse value for flag '%s': %v", flagName, err)
+		return err
+	}
+
+	if err := flag.checkRange(parsedValue); err != nil {
+		return err
+	}
+
+	// Run custom validators before the value is stored
+	for _, validate := range flag.validators {
+		if err := validate(parsedValue); err != nil {
+			return err
+		}
 	}
 
 	if err := flag.value.Set(parsedValue); err != nil {
-		return fmt.Errorf("failed to set value for flag '%s': %v", flagName, err)
+		return fmt.Errorf("failed to set value: %w", err)
 	}
 
 	// Store the successfully parsed value
@@ -107,15 +301,15 @@ func (df *DynFlags) setFlagValue(parsedGroup *ParsedGroup, flagName string, flag
 	return nil
 }
 
-// createOrGetParsedGroup retrieves or initializes a parsed group using the new GroupsMap/IdentifiersMap.
-func (df *DynFlags) createOrGetParsedGroup(parentGroup *ConfigGroup, identifier string) *ParsedGroup {
+// createOrGetParsedGroup retrieves or initializes a parsed group in the given GroupsMap.
+func (df *DynFlags) createOrGetParsedGroup(groups GroupsMap, parentGroup *ConfigGroup, identifier string) *ParsedGroup {
 	// Ensure the parent group name has an IdentifiersMap
-	if _, exists := df.parsedGroups[parentGroup.Name]; !exists {
-		df.parsedGroups[parentGroup.Name] = make(IdentifiersMap)
+	if _, exists := groups[parentGroup.Name]; !exists {
+		groups[parentGroup.Name] = make(IdentifiersMap)
 	}
 
 	// Check if we already have a ParsedGroup for this identifier
-	if existingGroup, ok := df.parsedGroups[parentGroup.Name][identifier]; ok {
+	if existingGroup, ok := groups[parentGroup.Name][identifier]; ok {
 		return existingGroup
 	}
 
@@ -125,6 +319,42 @@ func (df *DynFlags) createOrGetParsedGroup(parentGroup *ConfigGroup, identifier
 		Name:   identifier,
 		Values: make(map[string]any),
 	}
-	df.parsedGroups[parentGroup.Name][identifier] = newGroup
+	groups[parentGroup.Name][identifier] = newGroup
+	return newGroup
+}
+
+// createOrGetGlobalGroup retrieves or initializes the parsed group-level flags of a group.
+func (df *DynFlags) createOrGetGlobalGroup(globals map[string]*ParsedGroup, parentGroup *ConfigGroup) *ParsedGroup {
+	if existingGroup, ok := globals[parentGroup.Name]; ok {
+		return existingGroup
+	}
+
+	newGroup := &ParsedGroup{
+		Parent: parentGroup.global,
+		Name:   parentGroup.Name,
+		Values: make(map[string]any),
+	}
+	globals[parentGroup.Name] = newGroup
 	return newGroup
 }
+
+// applyWildcards passes the values of the wildcard identifier on to all other identifiers
+// of the group and removes the wildcard identifier from the parsed groups.
+// Groups that were only passed with the wildcard identifier are removed entirely.
+func (df *DynFlags) applyWildcards(groups GroupsMap) {
+	for groupName, identifiers := range groups {
+		wildcard, exists := identifiers[df.wildcard]
+		delete(identifiers, df.wildcard)
+		if len(identifiers) == 0 {
+			delete(groups, groupName)
+			continue
+		}
+
+		for _, group := range identifiers {
+			if exists {
+				group.inherit(wildcard)
+			}
+			df.applyWildcards(group.groups)
+		}
+	}
+}
@@ -1,6 +1,8 @@
 package dynflags_test
 
 import (
+	"bytes"
+	"io"
 	"testing"
 	"time"
 
@@ -8,6 +10,15 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
+// newExitOnError returns an ExitOnError instance that neither prints nor exits,
+// so the error returned by Parse can be inspected.
+func newExitOnError() *dynflags.DynFlags {
+	df := dynflags.New(dynflags.ExitOnError)
+	df.SetOutput(io.Discard)
+	df.SetExitFunc(func(int) {})
+	return df
+}
+
 func TestDynFlagsParse(t *testing.T) {
 	t.Parallel()
 
@@ -37,7 +48,7 @@ func TestDynFlagsParse(t *testing.T) {
 	})
 
 	t.Run("Exit on missing key", func(t *testing.T) {
-		df := dynflags.New(dynflags.ExitOnError)
+		df := newExitOnError()
 		group := df.Group("http")
 		group.String("method", "GET", "HTTP method to use")
 
@@ -100,7 +111,7 @@ func TestDynFlagsParse(t *testing.T) {
 	t.Run("Parse with no identifier and exit", func(t *testing.T) {
 		t.Parallel()
 
-		df := dynflags.New(dynflags.ExitOnError)
+		df := newExitOnError()
 		group1 := df.Group("http")
 		group1.Duration("timeout", 10*time.Second, "HTTP timeout")
 
@@ -114,7 +125,7 @@ func TestDynFlagsParse(t *testing.T) {
 	t.Run("Parse with unknown group and exit on error", func(t *testing.T) {
 		t.Parallel()
 
-		df := dynflags.New(dynflags.ExitOnError)
+		df := newExitOnError()
 
 		args := []string{
 			"--unknown.identifier1.flag1", "value1",
@@ -156,3 +167,526 @@ func TestDynFlagsParse(t *testing.T) {
 		assert.Contains(t, unparsedArgs, "--http.identifier1.method")
 	})
 }
+
+func TestDynFlagsParsePrefixStyle(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Single dash prefix", func(t *testing.T) {
+		t.Parallel()
+
+		df := dynflags.New(dynflags.ContinueOnError)
+		df.SetPrefixStyle(dynflags.PrefixSingleDash)
+		group := df.Group("http")
+		group.Duration("timeout", time.Second, "HTTP timeout")
+		group.Int("retries", 0, "Retries")
+
+		args := []string{
+			"-http.a.timeout=5s",
+			"-http.b.retries", "-1",
+			"--http.c.timeout=1s",
+		}
+		err := df.Parse(args)
+		assert.NoError(t, err)
+
+		http := df.Parsed().Lookup("http")
+		assert.Equal(t, 5*time.Second, http.Lookup("a").Lookup("timeout"))
+		assert.Equal(t, -1, http.Lookup("b").Lookup("retries"))
+		assert.Nil(t, http.Lookup("c"))
+		assert.Equal(t, []string{"--http.c.timeout=1s"}, df.UnknownArgs())
+	})
+
+	t.Run("Any dash prefix", func(t *testing.T) {
+		t.Parallel()
+
+		df := dynflags.New(dynflags.ContinueOnError)
+		df.SetPrefixStyle(dynflags.PrefixAnyDash)
+		group := df.Group("http")
+		group.String("method", "GET", "HTTP method")
+
+		args := []string{
+			"-http.a.method", "POST",
+			"--http.b.method", "PUT",
+		}
+		err := df.Parse(args)
+		assert.NoError(t, err)
+
+		http := df.Parsed().Lookup("http")
+		assert.Equal(t, "POST", http.Lookup("a").Lookup("method"))
+		assert.Equal(t, "PUT", http.Lookup("b").Lookup("method"))
+	})
+
+	t.Run("No prefix", func(t *testing.T) {
+		t.Parallel()
+
+		df := dynflags.New(dynflags.ContinueOnError)
+		df.SetPrefixStyle(dynflags.PrefixNone)
+		group := df.Group("http")
+		group.Duration("timeout", time.Second, "HTTP timeout")
+
+		args := []string{
+			"http.a.timeout=5s",
+			"http.b.timeout", "3s",
+			"--verbose",
+		}
+		err := df.Parse(args)
+		assert.NoError(t, err)
+
+		http := df.Parsed().Lookup("http")
+		assert.Equal(t, 5*time.Second, http.Lookup("a").Lookup("timeout"))
+		assert.Nil(t, http.Lookup("b"))
+		assert.Equal(t, []string{"http.b.timeout", "3s", "--verbose"}, df.UnknownArgs())
+	})
+
+	t.Run("Default prefix rejects single dash", func(t *testing.T) {
+		t.Parallel()
+
+		df := dynflags.New(dynflags.ContinueOnError)
+		group := df.Group("http")
+		group.String("method", "GET", "HTTP method")
+
+		err := df.Parse([]string{"-http.a.method=POST"})
+		assert.NoError(t, err)
+		assert.Nil(t, df.Parsed().Lookup("http"))
+		assert.Contains(t, df.UnknownArgs(), "-http.a.method=POST")
+	})
+}
+
+func TestDynFlagsParseSeparator(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Custom separator", func(t *testing.T) {
+		t.Parallel()
+
+		df := dynflags.New(dynflags.ContinueOnError)
+		df.SetSeparator(":")
+		group := df.Group("http")
+		group.Duration("timeout", time.Second, "HTTP timeout")
+
+		err := df.Parse([]string{"--http:api.example.com:timeout=5s"})
+		assert.NoError(t, err)
+
+		identifier := df.Parsed().Lookup("http").Lookup("api.example.com")
+		assert.NotNil(t, identifier)
+		assert.Equal(t, 5*time.Second, identifier.Lookup("timeout"))
+	})
+
+	t.Run("Bracket quoted identifier", func(t *testing.T) {
+		t.Parallel()
+
+		df := dynflags.New(dynflags.ContinueOnError)
+		group := df.Group("http")
+		group.Duration("timeout", time.Second, "HTTP timeout")
+
+		err := df.Parse([]string{"--http.[api.example.com].timeout", "5s"})
+		assert.NoError(t, err)
+
+		identifier := df.Parsed().Lookup("http").Lookup("api.example.com")
+		assert.NotNil(t, identifier)
+		assert.Equal(t, 5*time.Second, identifier.Lookup("timeout"))
+	})
+
+	t.Run("Escaped separator", func(t *testing.T) {
+		t.Parallel()
+
+		df := dynflags.New(dynflags.ContinueOnError)
+		group := df.Group("http")
+		group.Duration("timeout", time.Second, "HTTP timeout")
+
+		err := df.Parse([]string{`--http.api\.example\.com.timeout=5s`})
+		assert.NoError(t, err)
+
+		identifier := df.Parsed().Lookup("http").Lookup("api.example.com")
+		assert.NotNil(t, identifier)
+	})
+
+	t.Run("Unterminated bracket", func(t *testing.T) {
+		t.Parallel()
+
+		df := newExitOnError()
+		df.Group("http").String("method", "GET", "HTTP method")
+
+		err := df.Parse([]string{"--http.[api.example.com.method=POST"})
+		assert.EqualError(t, err, "unterminated '[' in key: http.[api.example.com.method")
+	})
+
+	t.Run("Trailing characters after bracket", func(t *testing.T) {
+		t.Parallel()
+
+		df := newExitOnError()
+		df.Group("http").String("method", "GET", "HTTP method")
+
+		err := df.Parse([]string{"--http.[api]x.method=POST"})
+		assert.EqualError(t, err, "unexpected character after ']' in key: http.[api]x.method")
+	})
+
+	t.Run("Pattern error uses separator", func(t *testing.T) {
+		t.Parallel()
+
+		df := newExitOnError()
+		df.SetSeparator("/")
+		df.Group("http").String("method", "GET", "HTTP method")
+
+		err := df.Parse([]string{"--http=POST"})
+		assert.EqualError(t, err, "flag must follow the pattern: --<group>/<identifier>/<flag>")
+	})
+
+	t.Run("Empty separator panics", func(t *testing.T) {
+		t.Parallel()
+
+		df := dynflags.New(dynflags.ContinueOnError)
+		assert.Panics(t, func() { df.SetSeparator("") })
+	})
+}
+
+func TestDynFlagsParseNestedGroups(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Parse nested group flags", func(t *testing.T) {
+		t.Parallel()
+
+		df := dynflags.New(dynflags.ContinueOnError)
+		cluster := df.Group("cluster")
+		cluster.String("region", "", "Cluster region")
+		node := cluster.Group("node")
+		node.String("address", "", "Node address")
+		node.Group("disk").Int("size", 0, "Disk size")
+
+		args := []string{
+			"--cluster.eu.region", "eu-west-1",
+			"--cluster.eu.node.n1.address", "10.0.0.1",
+			"--cluster.eu.node.n2.address=10.0.0.2",
+			"--cluster.eu.node.n1.disk.d1.size=20",
+			"--cluster.us.node.n1.address", "10.1.0.1",
+		}
+		err := df.Parse(args)
+		assert.NoError(t, err)
+		assert.Empty(t, df.UnknownArgs())
+
+		eu := df.Parsed().Lookup("cluster").Lookup("eu")
+		assert.Equal(t, "eu-west-1", eu.Lookup("region"))
+
+		nodes := eu.Sub("node")
+		assert.Equal(t, "node", nodes.Name)
+		assert.Equal(t, "10.0.0.1", nodes.Lookup("n1").Lookup("address"))
+		assert.Equal(t, "10.0.0.2", nodes.Lookup("n2").Lookup("address"))
+		assert.Equal(t, node, nodes.Lookup("n1").Parent)
+		assert.Equal(t, 20, nodes.Lookup("n1").Sub("disk").Lookup("d1").Lookup("size"))
+
+		us := df.Parsed().Lookup("cluster").Lookup("us")
+		assert.Nil(t, us.Lookup("region"))
+		assert.Equal(t, "10.1.0.1", us.Sub("node").Lookup("n1").Lookup("address"))
+	})
+
+	t.Run("Unknown nested flag", func(t *testing.T) {
+		t.Parallel()
+
+		df := newExitOnError()
+		df.Group("cluster").Group("node").String("address", "", "Node address")
+
+		err := df.Parse([]string{"--cluster.eu.node.n1.port=80"})
+		assert.EqualError(t, err, "unknown flag 'port' in group 'cluster.eu.node'")
+	})
+
+	t.Run("Unknown child group", func(t *testing.T) {
+		t.Parallel()
+
+		df := dynflags.New(dynflags.ContinueOnError)
+		df.Group("cluster").Group("node").String("address", "", "Node address")
+
+		err := df.Parse([]string{"--cluster.eu.disk.d1.address=x"})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"--cluster.eu.disk.d1.address=x"}, df.UnknownArgs())
+		assert.Nil(t, df.Parsed().Lookup("cluster"))
+	})
+
+	t.Run("Invalid nested value names the parent identifier", func(t *testing.T) {
+		t.Parallel()
+
+		df := newExitOnError()
+		df.Group("cluster").Group("node").Int("port", 0, "Node port")
+
+		err := df.Parse([]string{"--cluster.eu.node.n1.port=80", "--cluster.us.node.n1.port=abc"})
+		var flagErr *dynflags.FlagError
+		assert.ErrorAs(t, err, &flagErr)
+		assert.Equal(t, "cluster.us.node", flagErr.Group)
+		assert.Equal(t, "n1", flagErr.Identifier)
+	})
+
+	t.Run("Missing child identifier", func(t *testing.T) {
+		t.Parallel()
+
+		df := newExitOnError()
+		df.Group("cluster").Group("node").String("address", "", "Node address")
+
+		err := df.Parse([]string{"--cluster.eu.node.address=x"})
+		assert.EqualError(t, err, "unknown flag 'address' in group 'cluster.eu.node'")
+	})
+}
+
+func TestDynFlagsParseGlobalFlags(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Parse group-level flags", func(t *testing.T) {
+		t.Parallel()
+
+		df := dynflags.New(dynflags.ContinueOnError)
+		http := df.Group("http")
+		http.Global().Int("concurrency", 1, "Concurrent checks")
+		http.String("method", "GET", "HTTP method")
+
+		args := []string{
+			"--http.concurrency=10",
+			"--http.a.method", "POST",
+		}
+		err := df.Parse(args)
+		assert.NoError(t, err)
+		assert.Empty(t, df.UnknownArgs())
+
+		parsed := df.Parsed().Lookup("http")
+		assert.Equal(t, 10, parsed.Global().Lookup("concurrency"))
+		assert.Equal(t, "http", parsed.Global().Name)
+		assert.Equal(t, http.Global(), parsed.Global().Parent)
+		assert.Equal(t, "POST", parsed.Lookup("a").Lookup("method"))
+		assert.Nil(t, parsed.Lookup("a").Lookup("concurrency"))
+	})
+
+	t.Run("Group with only group-level flags", func(t *testing.T) {
+		t.Parallel()
+
+		df := dynflags.New(dynflags.ContinueOnError)
+		df.Group("http").Global().Int("concurrency", 1, "Concurrent checks")
+
+		err := df.Parse([]string{"--http.concurrency", "5"})
+		assert.NoError(t, err)
+
+		parsed := df.Parsed().Lookup("http")
+		assert.NotNil(t, parsed)
+		assert.Nil(t, parsed.Lookup("concurrency"))
+		assert.Equal(t, 5, parsed.Global().Lookup("concurrency"))
+		assert.Empty(t, df.Parsed().Groups())
+	})
+
+	t.Run("Group-level flags of child groups", func(t *testing.T) {
+		t.Parallel()
+
+		df := dynflags.New(dynflags.ContinueOnError)
+		node := df.Group("cluster").Group("node")
+		node.Global().Int("retries", 0, "Retries per node")
+		node.String("address", "", "Node address")
+
+		args := []string{
+			"--cluster.eu.node.retries=3",
+			"--cluster.eu.node.n1.address=10.0.0.1",
+		}
+		err := df.Parse(args)
+		assert.NoError(t, err)
+
+		nodes := df.Parsed().Lookup("cluster").Lookup("eu").Sub("node")
+		assert.Equal(t, 3, nodes.Global().Lookup("retries"))
+		assert.Equal(t, "10.0.0.1", nodes.Lookup("n1").Lookup("address"))
+	})
+
+	t.Run("Identifier flag is not a group-level flag", func(t *testing.T) {
+		t.Parallel()
+
+		df := newExitOnError()
+		df.Group("http").String("method", "GET", "HTTP method")
+
+		err := df.Parse([]string{"--http.method=POST"})
+		assert.EqualError(t, err, "unknown flag 'method' in group 'http'")
+	})
+}
+
+func TestDynFlagsParseWildcard(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Wildcard values are inherited", func(t *testing.T) {
+		t.Parallel()
+
+		df := dynflags.New(dynflags.ContinueOnError)
+		http := df.Group("http")
+		http.Duration("timeout", time.Second, "HTTP timeout")
+		http.String("method", "GET", "HTTP method")
+
+		args := []string{
+			"--http.a.timeout=5s",
+			"--http.*.timeout=10s",
+			"--http.*.method=POST",
+			"--http.b.method=PUT",
+		}
+		err := df.Parse(args)
+		assert.NoError(t, err)
+
+		parsed := df.Parsed().Lookup("http")
+		assert.Nil(t, parsed.Lookup("*"))
+		assert.Len(t, df.Parsed().Groups()["http"], 2)
+
+		a := parsed.Lookup("a")
+		assert.Equal(t, 5*time.Second, a.Lookup("timeout"))
+		assert.Equal(t, "POST", a.Lookup("method"))
+
+		b := parsed.Lookup("b")
+		assert.Equal(t, 10*time.Second, b.Lookup("timeout"))
+		assert.Equal(t, "PUT", b.Lookup("method"))
+	})
+
+	t.Run("Wildcard alone does not pass the group", func(t *testing.T) {
+		t.Parallel()
+
+		df := dynflags.New(dynflags.ContinueOnError)
+		df.Group("http").Duration("timeout", time.Second, "HTTP timeout")
+		df.RequireGroup("http")
+
+		err := df.Parse([]string{"--http.*.timeout=5s"})
+		assert.EqualError(t, err, "group 'http' is required")
+		assert.Nil(t, df.Parsed().Lookup("http"))
+		assert.NotContains(t, df.Parsed().Groups(), "http")
+	})
+
+	t.Run("Custom wildcard identifier", func(t *testing.T) {
+		t.Parallel()
+
+		df := dynflags.New(dynflags.ContinueOnError)
+		df.SetWildcard("default")
+		http := df.Group("http")
+		http.Duration("timeout", time.Second, "HTTP timeout")
+
+		args := []string{
+			"--http.default.timeout=10s",
+			"--http.a.timeout=5s",
+			"--http.b.timeout=2s",
+			"--http.*.timeout=1s",
+		}
+		err := df.Parse(args)
+		assert.NoError(t, err)
+
+		parsed := df.Parsed().Lookup("http")
+		assert.Nil(t, parsed.Lookup("default"))
+		assert.Equal(t, 5*time.Second, parsed.Lookup("a").Lookup("timeout"))
+		assert.Equal(t, 1*time.Second, parsed.Lookup("*").Lookup("timeout"))
+	})
+
+	t.Run("Disabled wildcard", func(t *testing.T) {
+		t.Parallel()
+
+		df := dynflags.New(dynflags.ContinueOnError)
+		df.SetWildcard("")
+		http := df.Group("http")
+		http.Duration("timeout", time.Second, "HTTP timeout")
+
+		err := df.Parse([]string{"--http.*.timeout=10s", "--http.a.timeout=5s"})
+		assert.NoError(t, err)
+
+		parsed := df.Parsed().Lookup("http")
+		assert.Equal(t, 10*time.Second, parsed.Lookup("*").Lookup("timeout"))
+		assert.Equal(t, 5*time.Second, parsed.Lookup("a").Lookup("timeout"))
+	})
+
+	t.Run("Wildcard in nested groups", func(t *testing.T) {
+		t.Parallel()
+
+		df := dynflags.New(dynflags.ContinueOnError)
+		cluster := df.Group("cluster")
+		cluster.String("region", "", "Cluster region")
+		node := cluster.Group("node")
+		node.Int("port", 0, "Node port")
+		node.String("address", "", "Node address")
+
+		args := []string{
+			"--cluster.*.node.*.port=8080",
+			"--cluster.*.node.shared.address=10.0.0.100",
+			"--cluster.eu.node.n1.address=10.0.0.1",
+			"--cluster.eu.node.n2.port=9090",
+			"--cluster.us.region=us-east-1",
+		}
+		err := df.Parse(args)
+		assert.NoError(t, err)
+
+		eu := df.Parsed().Lookup("cluster").Lookup("eu").Sub("node")
+		assert.Nil(t, eu.Lookup("*"))
+		assert.Equal(t, 8080, eu.Lookup("n1").Lookup("port"))
+		assert.Equal(t, "10.0.0.1", eu.Lookup("n1").Lookup("address"))
+		assert.Equal(t, 9090, eu.Lookup("n2").Lookup("port"))
+		assert.Equal(t, "10.0.0.100", eu.Lookup("shared").Lookup("address"))
+		assert.Equal(t, 8080, eu.Lookup("shared").Lookup("port"))
+
+		us := df.Parsed().Lookup("cluster").Lookup("us")
+		assert.Equal(t, "us-east-1", us.Lookup("region"))
+		assert.Equal(t, 8080, us.Sub("node").Lookup("shared").Lookup("port"))
+	})
+}
+
+func TestDynFlagsParseHidden(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Hidden flags and groups still parse", func(t *testing.T) {
+		t.Parallel()
+
+		df := newExitOnError()
+		df.Group("http").Int("buffer-size", 4096, "Read buffer size").Hidden()
+		debug := df.Group("debug")
+		debug.Hidden()
+		debug.Bool("pprof", false, "Enable pprof")
+
+		err := df.Parse([]string{"--http.a.buffer-size=1024", "--debug.a.pprof=true"})
+		assert.NoError(t, err)
+		assert.Equal(t, 1024, df.Parsed().Lookup("http").Lookup("a").Lookup("buffer-size"))
+		assert.Equal(t, true, df.Parsed().Lookup("debug").Lookup("a").Lookup("pprof"))
+	})
+}
+
+func TestDynFlagsParseBehavior(t *testing.T) {
+	t.Parallel()
+
+	t.Run("ExitOnError prints error and usage and exits with status 2", func(t *testing.T) {
+		t.Parallel()
+
+		df := dynflags.New(dynflags.ExitOnError)
+		df.Group("http").String("method", "GET", "HTTP method")
+		var output bytes.Buffer
+		df.SetOutput(&output)
+		code := -1
+		df.SetExitFunc(func(c int) { code = c })
+
+		err := df.Parse([]string{"--http.a.methd", "POST"})
+		assert.EqualError(t, err, "unknown flag 'methd' in group 'http'; did you mean 'method'?")
+		assert.Equal(t, 2, code)
+		assert.Contains(t, output.String(), "unknown flag 'methd' in group 'http'; did you mean 'method'?\nUsage: [--<group>.<identifier>.<flag> value]")
+		assert.Contains(t, output.String(), "--http.<IDENTIFIER>.method STRING")
+	})
+
+	t.Run("ExitOnError exits on validation errors", func(t *testing.T) {
+		t.Parallel()
+
+		df := newExitOnError()
+		df.Group("http").String("method", "GET", "HTTP method")
+		df.RequireGroup("http")
+		code := -1
+		df.SetExitFunc(func(c int) { code = c })
+
+		err := df.Parse([]string{})
+		assert.EqualError(t, err, "group 'http' is required")
+		assert.Equal(t, 2, code)
+	})
+
+	t.Run("ExitOnError does not exit without errors", func(t *testing.T) {
+		t.Parallel()
+
+		df := dynflags.New(dynflags.ExitOnError)
+		df.Group("http").String("method", "GET", "HTTP method")
+		df.SetExitFunc(func(int) { t.Fatal("unexpected exit") })
+
+		assert.NoError(t, df.Parse([]string{"--http.a.method", "POST"}))
+	})
+
+	t.Run("PanicOnError panics with the error", func(t *testing.T) {
+		t.Parallel()
+
+		df := dynflags.New(dynflags.PanicOnError)
+		df.Group("http").String("method", "GET", "HTTP method")
+
+		assert.PanicsWithError(t, "unknown flag 'verb' in group 'http'", func() {
+			_ = df.Parse([]string{"--http.a.verb", "POST"})
+		})
+	})
+}
@@ -0,0 +1,118 @@
+package dynflags
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Min sets the lower bound for the values of an Int, Float64 or Duration flag or their slice variants.
+// It panics if the bound does not match the type of the flag.
+func (f *Flag) Min(lower any) *Flag {
+	f.min = f.checkBound(lower)
+	return f
+}
+
+// Max sets the upper bound for the values of an Int, Float64 or Duration flag or their slice variants.
+// It panics if the bound does not match the type of the flag.
+func (f *Flag) Max(upper any) *Flag {
+	f.max = f.checkBound(upper)
+	return f
+}
+
+// Range sets the lower and upper bound for the values of an Int, Float64 or Duration flag or their slice variants.
+// It panics if the bounds do not match the type of the flag or lower is greater than upper.
+func (f *Flag) Range(lower, upper any) *Flag {
+	f.Min(lower).Max(upper)
+	if compareBounds(f.min, f.max) > 0 {
+		panic(fmt.Sprintf("dynflags: minimum %s is greater than maximum %s", formatBound(f.min), formatBound(f.max)))
+	}
+	return f
+}
+
+// checkBound converts a bound to the value type of the flag.
+func (f *Flag) checkBound(bound any) any {
+	switch f.Type {
+	case FlagTypeInt, FlagTypeIntSlice:
+		if n, ok := bound.(int); ok {
+			return n
+		}
+	case FlagTypeFloat, FlagTypeFloatSlice:
+		switch n := bound.(type) {
+		case float64:
+			return n
+		case int:
+			return float64(n)
+		}
+	case FlagTypeDuration, FlagTypeDurationSlice:
+		if d, ok := bound.(time.Duration); ok {
+			return d
+		}
+	}
+	panic(fmt.Sprintf("dynflags: invalid bound %v (%T) for flag of type %s", bound, bound, f.Type))
+}
+
+// checkRange returns an error if the parsed value is outside the bounds of the flag.
+func (f *Flag) checkRange(value any) error {
+	if f.min != nil && compareBounds(value, f.min) < 0 {
+		return fmt.Errorf("value %s is below the minimum of %s", formatBound(value), formatBound(f.min))
+	}
+	if f.max != nil && compareBounds(value, f.max) > 0 {
+		return fmt.Errorf("value %s is above the maximum of %s", formatBound(value), formatBound(f.max))
+	}
+	return nil
+}
+
+// rangeString renders the bounds of the flag for the help message, e.g. "[1s..1m]".
+func (f *Flag) rangeString() string {
+	if f.min == nil && f.max == nil {
+		return ""
+	}
+	var lower, upper string
+	if f.min != nil {
+		lower = formatBound(f.min)
+	}
+	if f.max != nil {
+		upper = formatBound(f.max)
+	}
+	return fmt.Sprintf("[%s..%s]", lower, upper)
+}
+
+// compareBounds compares two values of the same numeric or duration type.
+func compareBounds(a, b any) int {
+	switch a := a.(type) {
+	case int:
+		return compare(a, b.(int))
+	case float64:
+		return compare(a, b.(float64))
+	case time.Duration:
+		return compare(a, b.(time.Duration))
+	}
+	return 0
+}
+
+func compare[T int | float64 | time.Duration](a, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// formatBound formats a bound, omitting zero minutes and seconds of durations ("1m" instead of "1m0s").
+func formatBound(bound any) string {
+	d, ok := bound.(time.Duration)
+	if !ok {
+		return fmt.Sprint(bound)
+	}
+	s := d.String()
+	if strings.HasSuffix(s, "m0s") {
+		s = strings.TrimSuffix(s, "0s")
+	}
+	if strings.HasSuffix(s, "h0m") {
+		s = strings.TrimSuffix(s, "0m")
+	}
+	return s
+}
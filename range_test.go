@@ -0,0 +1,105 @@
+package dynflags_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/containeroo/dynflags"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFlagRange(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Duration within range", func(t *testing.T) {
+		t.Parallel()
+
+		df := newExitOnError()
+		df.Group("http").Duration("timeout", 2*time.Second, "HTTP timeout").Range(time.Second, time.Minute)
+
+		err := df.Parse([]string{"--http.a.timeout=30s"})
+		assert.NoError(t, err)
+		assert.Equal(t, 30*time.Second, df.Parsed().Lookup("http").Lookup("a").Lookup("timeout"))
+	})
+
+	t.Run("Duration below minimum", func(t *testing.T) {
+		t.Parallel()
+
+		df := newExitOnError()
+		df.Group("http").Duration("timeout", 2*time.Second, "HTTP timeout").Range(time.Second, time.Minute)
+
+		err := df.Parse([]string{"--http.a.timeout=500ms"})
+		assert.EqualError(t, err, "invalid value for flag 'timeout' in group 'http' (identifier 'a'): value 500ms is below the minimum of 1s")
+		assert.Nil(t, df.Parsed().Lookup("http").Lookup("a").Lookup("timeout"))
+	})
+
+	t.Run("Duration above maximum", func(t *testing.T) {
+		t.Parallel()
+
+		df := newExitOnError()
+		df.Group("http").Duration("timeout", 2*time.Second, "HTTP timeout").Max(time.Minute)
+
+		err := df.Parse([]string{"--http.a.timeout=2m"})
+		assert.EqualError(t, err, "invalid value for flag 'timeout' in group 'http' (identifier 'a'): value 2m is above the maximum of 1m")
+	})
+
+	t.Run("Int slice minimum", func(t *testing.T) {
+		t.Parallel()
+
+		df := dynflags.New(dynflags.ContinueOnError)
+		df.Group("http").IntSlices("status", nil, "Expected status codes").Range(100, 599)
+
+		err := df.Parse([]string{"--http.a.status=200", "--http.a.status=99"})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"--http.a.status=99"}, df.UnknownArgs())
+	})
+
+	t.Run("Float accepts int bounds", func(t *testing.T) {
+		t.Parallel()
+
+		df := newExitOnError()
+		df.Group("http").Float64("ratio", 0.5, "Success ratio").Range(0, 1)
+
+		assert.NoError(t, df.Parse([]string{"--http.a.ratio=0.9"}))
+		assert.EqualError(t, df.Parse([]string{"--http.b.ratio=1.5"}), "invalid value for flag 'ratio' in group 'http' (identifier 'b'): value 1.5 is above the maximum of 1")
+	})
+
+	t.Run("Bound of wrong type panics", func(t *testing.T) {
+		t.Parallel()
+
+		group := &dynflags.ConfigGroup{Flags: make(map[string]*dynflags.Flag)}
+		assert.Panics(t, func() { group.Duration("timeout", time.Second, "Timeout").Min(1) })
+		assert.Panics(t, func() { group.String("name", "", "Name").Max(10) })
+	})
+
+	t.Run("Minimum greater than maximum panics", func(t *testing.T) {
+		t.Parallel()
+
+		group := &dynflags.ConfigGroup{Flags: make(map[string]*dynflags.Flag)}
+		assert.Panics(t, func() { group.Int("retries", 1, "Retries").Range(10, 1) })
+	})
+}
+
+func TestPrintDefaultsRange(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Range is rendered after usage", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+		df := dynflags.New(dynflags.ContinueOnError)
+		df.SetOutput(&buf)
+		http := df.Group("http")
+		http.Duration("timeout", 2*time.Second, "HTTP timeout").Range(time.Second, time.Minute)
+		http.Int("retries", 0, "Retries").Min(0)
+		http.Duration("interval", 0, "Interval").Max(2 * time.Hour)
+
+		df.PrintDefaults()
+
+		output := buf.String()
+		assert.Contains(t, output, "HTTP timeout (default: 2s) [1s..1m]")
+		assert.Contains(t, output, "Retries (default: 0) [0..]")
+		assert.Contains(t, output, "Interval (default: 0s) [..2h]")
+	})
+}
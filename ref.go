@@ -0,0 +1,147 @@
+package dynflags
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+type RefValue struct {
+	Bound  *string
+	Target string // Group whose identifiers are valid values
+}
+
+func (r *RefValue) GetBound() any {
+	if r.Bound == nil {
+		return nil
+	}
+	return *r.Bound
+}
+
+func (r *RefValue) Parse(value string) (any, error) {
+	if value == "" {
+		return nil, fmt.Errorf("reference to group '%s' must not be empty", r.Target)
+	}
+	return value, nil
+}
+
+func (r *RefValue) Set(value any) error {
+	if str, ok := value.(string); ok {
+		*r.Bound = str
+		return nil
+	}
+	return fmt.Errorf("invalid value type: expected string")
+}
+
+// Ref defines a flag whose value must be an identifier of the target group or the group a group alias refers to.
+// The reference is checked after parsing, once all identifiers are known.
+func (g *ConfigGroup) Ref(name, targetGroup, usage string) *Flag {
+	bound := new(string)
+	flag := &Flag{
+		Type:  FlagTypeRef,
+		Usage: usage,
+		value: &RefValue{Bound: bound, Target: targetGroup},
+	}
+	g.addFlag(name, flag)
+	return flag
+}
+
+// GetRef returns the parsed group referenced by the Ref flag with the given name.
+// References are resolved at the end of Parse.
+func (pg *ParsedGroup) GetRef(flagName string) (*ParsedGroup, error) {
+	if _, exists := pg.Values[flagName]; !exists {
+		return nil, fmt.Errorf("flag '%s' not found in group '%s'", flagName, pg.Name)
+	}
+	if target, ok := pg.refs[flagName]; ok {
+		return target, nil
+	}
+	return nil, fmt.Errorf("flag '%s' is not a resolved reference", flagName)
+}
+
+// checkRefs resolves the references of the parsed group and returns an error
+// for every reference to an identifier that was not passed.
+func (df *DynFlags) checkRefs(pg *ParsedGroup) []error {
+	var errs []error
+	for _, flagName := range pg.Parent.flagOrder {
+		ref, ok := pg.Parent.Flags[flagName].value.(*RefValue)
+		if !ok {
+			continue
+		}
+		value, set := pg.Values[flagName].(string)
+		if !set {
+			continue
+		}
+		// The target may be an alias of the group
+		targetGroup, _ := df.resolveGroup(ref.Target)
+		target, exists := df.parsedGroups[targetGroup][value]
+		if !exists {
+			errs = append(errs, fmt.Errorf("flag '%s' refers to unknown identifier '%s' of group '%s'", flagName, value, ref.Target))
+			continue
+		}
+		if pg.refs == nil {
+			pg.refs = make(map[string]*ParsedGroup)
+		}
+		pg.refs[flagName] = target
+	}
+	return errs
+}
+
+// checkRefCycles returns an error for every cycle formed by resolved references.
+// Only identifiers of top-level groups can be referenced, so every cycle consists of them.
+func (df *DynFlags) checkRefCycles() []error {
+	const (
+		visiting = iota + 1
+		visited
+	)
+	state := make(map[*ParsedGroup]int)
+	var stack []*ParsedGroup
+	var errs []error
+
+	var visit func(pg *ParsedGroup)
+	visit = func(pg *ParsedGroup) {
+		state[pg] = visiting
+		stack = append(stack, pg)
+		for _, flagName := range pg.Parent.flagOrder {
+			target, ok := pg.refs[flagName]
+			if !ok {
+				continue
+			}
+			switch state[target] {
+			case visiting:
+				errs = append(errs, df.refCycleError(stack, target))
+			case 0:
+				visit(target)
+			}
+		}
+		stack = stack[:len(stack)-1]
+		state[pg] = visited
+	}
+
+	for _, groupName := range df.groupOrder {
+		identifiers := make([]string, 0, len(df.parsedGroups[groupName]))
+		for identifier := range df.parsedGroups[groupName] {
+			identifiers = append(identifiers, identifier)
+		}
+		sort.Strings(identifiers)
+
+		for _, identifier := range identifiers {
+			if pg := df.parsedGroups[groupName][identifier]; state[pg] == 0 {
+				visit(pg)
+			}
+		}
+	}
+	return errs
+}
+
+// refCycleError describes the cycle from target to the end of the stack and back to target.
+func (df *DynFlags) refCycleError(stack []*ParsedGroup, target *ParsedGroup) error {
+	var path []string
+	for i := len(stack) - 1; i >= 0; i-- {
+		path = append([]string{df.keyPattern(stack[i].Parent.Name, stack[i].Name)}, path...)
+		if stack[i] == target {
+			break
+		}
+	}
+	path = append(path, df.keyPattern(target.Parent.Name, target.Name))
+	return fmt.Errorf("reference cycle: %s", strings.Join(path, " -> "))
+}
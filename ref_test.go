@@ -0,0 +1,215 @@
+package dynflags_test
+
+import (
+	"testing"
+
+	"github.com/containeroo/dynflags"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRefValue_Parse(t *testing.T) {
+	t.Parallel()
+
+	t.Run("ValidRef", func(t *testing.T) {
+		t.Parallel()
+
+		r := &dynflags.RefValue{Target: "backend"}
+		value, err := r.Parse("b1")
+		assert.NoError(t, err)
+		assert.Equal(t, "b1", value)
+	})
+
+	t.Run("EmptyRef", func(t *testing.T) {
+		t.Parallel()
+
+		r := &dynflags.RefValue{Target: "backend"}
+		_, err := r.Parse("")
+		assert.EqualError(t, err, "reference to group 'backend' must not be empty")
+	})
+}
+
+func TestRefValue_Set(t *testing.T) {
+	t.Parallel()
+
+	t.Run("SetValidRef", func(t *testing.T) {
+		t.Parallel()
+
+		var bound string
+		r := &dynflags.RefValue{Bound: &bound, Target: "backend"}
+		assert.NoError(t, r.Set("b1"))
+		assert.Equal(t, "b1", bound)
+	})
+
+	t.Run("SetInvalidType", func(t *testing.T) {
+		t.Parallel()
+
+		var bound string
+		r := &dynflags.RefValue{Bound: &bound, Target: "backend"}
+		assert.EqualError(t, r.Set(1), "invalid value type: expected string")
+	})
+}
+
+func TestGroupConfig_Ref(t *testing.T) {
+	t.Parallel()
+
+	t.Run("RefDefinition", func(t *testing.T) {
+		t.Parallel()
+
+		group := &dynflags.ConfigGroup{Flags: make(map[string]*dynflags.Flag)}
+		flag := group.Ref("backend", "backend", "Backend of the route")
+		assert.Contains(t, group.Flags, "backend")
+		assert.Equal(t, dynflags.FlagTypeRef, flag.Type)
+		assert.Nil(t, flag.Default)
+		assert.Equal(t, "", flag.GetValue())
+	})
+}
+
+func TestParseRef(t *testing.T) {
+	t.Parallel()
+
+	newDynFlags := func() *dynflags.DynFlags {
+		df := dynflags.New(dynflags.ContinueOnError)
+		df.Group("backend").String("address", "", "Backend address")
+		df.Group("route").Ref("backend", "backend", "Backend of the route")
+		return df
+	}
+
+	t.Run("Reference to existing identifier", func(t *testing.T) {
+		t.Parallel()
+
+		df := newDynFlags()
+		args := []string{
+			"--backend.b1.address=10.0.0.1",
+			"--route.r1.backend=b1",
+		}
+		assert.NoError(t, df.Parse(args))
+		assert.Equal(t, "b1", df.Parsed().Lookup("route").Lookup("r1").Lookup("backend"))
+	})
+
+	t.Run("Reference to unknown identifier", func(t *testing.T) {
+		t.Parallel()
+
+		df := newDynFlags()
+		args := []string{
+			"--backend.b1.address=10.0.0.1",
+			"--route.r1.backend=b2",
+		}
+		err := df.Parse(args)
+		assert.EqualError(t, err, "flag 'backend' refers to unknown identifier 'b2' of group 'backend' in group 'route' (identifier 'r1')")
+
+		var validationErr *dynflags.ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+	})
+}
+
+func TestParsedGroup_GetRef(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Resolve reference", func(t *testing.T) {
+		t.Parallel()
+
+		df := dynflags.New(dynflags.ContinueOnError)
+		df.Group("backend").String("address", "", "Backend address")
+		route := df.Group("route")
+		route.Ref("backend", "backend", "Backend of the route")
+		route.String("path", "/", "Route path")
+
+		args := []string{
+			"--backend.b1.address=10.0.0.1",
+			"--route.r1.backend=b1",
+			"--route.r1.path=/api",
+		}
+		assert.NoError(t, df.Parse(args))
+
+		r1 := df.Parsed().Lookup("route").Lookup("r1")
+		backend, err := r1.GetRef("backend")
+		assert.NoError(t, err)
+		assert.Equal(t, df.Parsed().Lookup("backend").Lookup("b1"), backend)
+		assert.Equal(t, "10.0.0.1", backend.Lookup("address"))
+
+		_, err = r1.GetRef("path")
+		assert.EqualError(t, err, "flag 'path' is not a resolved reference")
+
+		_, err = r1.GetRef("missing")
+		assert.EqualError(t, err, "flag 'missing' not found in group 'r1'")
+	})
+
+	t.Run("Resolve reference from child group", func(t *testing.T) {
+		t.Parallel()
+
+		df := dynflags.New(dynflags.ContinueOnError)
+		df.Group("backend").String("address", "", "Backend address")
+		df.Group("proxy").Group("route").Ref("backend", "backend", "Backend of the route")
+
+		args := []string{
+			"--backend.b1.address=10.0.0.1",
+			"--proxy.p1.route.r1.backend=b1",
+		}
+		assert.NoError(t, df.Parse(args))
+
+		backend, err := df.Parsed().Lookup("proxy").Lookup("p1").Sub("route").Lookup("r1").GetRef("backend")
+		assert.NoError(t, err)
+		assert.Equal(t, "b1", backend.Name)
+	})
+}
+
+func TestParseRefCycles(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Cycle between identifiers", func(t *testing.T) {
+		t.Parallel()
+
+		df := dynflags.New(dynflags.ContinueOnError)
+		df.Group("route").Ref("next", "route", "Next route")
+
+		args := []string{
+			"--route.a.next=b",
+			"--route.b.next=c",
+			"--route.c.next=a",
+		}
+		err := df.Parse(args)
+		assert.EqualError(t, err, "reference cycle: route.a -> route.b -> route.c -> route.a")
+	})
+
+	t.Run("Cycle across groups", func(t *testing.T) {
+		t.Parallel()
+
+		df := dynflags.New(dynflags.ContinueOnError)
+		df.Group("backend").Ref("fallback", "route", "Fallback route")
+		df.Group("route").Ref("backend", "backend", "Backend of the route")
+
+		args := []string{
+			"--route.r1.backend=b1",
+			"--backend.b1.fallback=r1",
+		}
+		err := df.Parse(args)
+		assert.EqualError(t, err, "reference cycle: backend.b1 -> route.r1 -> backend.b1")
+	})
+
+	t.Run("Self reference", func(t *testing.T) {
+		t.Parallel()
+
+		df := dynflags.New(dynflags.ContinueOnError)
+		df.Group("route").Ref("next", "route", "Next route")
+
+		err := df.Parse([]string{"--route.a.next=a"})
+		assert.EqualError(t, err, "reference cycle: route.a -> route.a")
+	})
+
+	t.Run("Chain without cycle", func(t *testing.T) {
+		t.Parallel()
+
+		df := dynflags.New(dynflags.ContinueOnError)
+		route := df.Group("route")
+		route.Ref("next", "route", "Next route")
+		route.String("path", "/", "Route path")
+
+		args := []string{
+			"--route.a.next=b",
+			"--route.b.next=c",
+			"--route.c.path=/",
+			"--route.d.next=c",
+		}
+		assert.NoError(t, df.Parse(args))
+	})
+}
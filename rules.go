@@ -0,0 +1,285 @@
+package dynflags
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// ruleKind defines how the flags of a rule relate to each other.
+type ruleKind int
+
+const (
+	ruleMutuallyExclusive ruleKind = iota // At most one of the flags may be set
+	ruleRequiredTogether                  // Either all or none of the flags must be set
+	ruleOneRequired                       // At least one of the flags must be set
+)
+
+// String returns the name of the rule kind as used in the help model.
+func (k ruleKind) String() string {
+	switch k {
+	case ruleMutuallyExclusive:
+		return "mutuallyExclusive"
+	case ruleRequiredTogether:
+		return "requiredTogether"
+	default:
+		return "oneRequired"
+	}
+}
+
+// rule is a constraint between flags of the same identifier.
+type rule struct {
+	kind  ruleKind
+	flags []string
+}
+
+// MutuallyExclusive declares that at most one of the given flags may be set per identifier.
+// It panics if fewer than two flags are given or a flag is not defined.
+func (cg *ConfigGroup) MutuallyExclusive(flagNames ...string) {
+	cg.addRule(ruleMutuallyExclusive, flagNames, 2)
+}
+
+// RequiredTogether declares that either all or none of the given flags must be set per identifier.
+// It panics if fewer than two flags are given or a flag is not defined.
+func (cg *ConfigGroup) RequiredTogether(flagNames ...string) {
+	cg.addRule(ruleRequiredTogether, flagNames, 2)
+}
+
+// OneRequired declares that at least one of the given flags must be set per identifier.
+// It panics if no flag is given or a flag is not defined.
+func (cg *ConfigGroup) OneRequired(flagNames ...string) {
+	cg.addRule(ruleOneRequired, flagNames, 1)
+}
+
+// addRule adds a rule after checking that it names at least minFlags defined flags.
+func (cg *ConfigGroup) addRule(kind ruleKind, flagNames []string, minFlags int) {
+	if len(flagNames) < minFlags {
+		panic(fmt.Sprintf("dynflags: rule in group '%s' requires at least %s, got %d", cg.Name, pluralize(minFlags, "flag"), len(flagNames)))
+	}
+	for _, flagName := range flagNames {
+		if _, exists := cg.Flags[flagName]; !exists {
+			panic(fmt.Sprintf("dynflags: rule refers to unknown flag '%s' in group '%s'", flagName, cg.Name))
+		}
+	}
+	cg.rules = append(cg.rules, rule{kind: kind, flags: flagNames})
+}
+
+// excludes reports whether a MutuallyExclusive rule of the group pairs flagName with one of the set flags.
+func (cg *ConfigGroup) excludes(flagName string, values map[string]any) bool {
+	if cg == nil {
+		return false
+	}
+	for _, r := range cg.rules {
+		if r.kind != ruleMutuallyExclusive || !slices.Contains(r.flags, flagName) {
+			continue
+		}
+		for _, other := range r.flags {
+			if _, set := values[other]; set && other != flagName {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// MinIdentifiers declares that at least n identifiers of the group must be passed.
+// For child groups, the limit applies per identifier of the parent group.
+func (cg *ConfigGroup) MinIdentifiers(n int) {
+	cg.checkNotGlobal("identifier limits")
+	if n < 0 {
+		panic(fmt.Sprintf("dynflags: invalid minimum of %d identifiers for group '%s'", n, cg.Name))
+	}
+	cg.minIdentifiers = n
+}
+
+// MaxIdentifiers declares that at most n identifiers of the group may be passed.
+// For child groups, the limit applies per identifier of the parent group.
+func (cg *ConfigGroup) MaxIdentifiers(n int) {
+	cg.checkNotGlobal("identifier limits")
+	if n < 1 {
+		panic(fmt.Sprintf("dynflags: invalid maximum of %d identifiers for group '%s'", n, cg.Name))
+	}
+	cg.maxIdentifiers = n
+}
+
+// RequireGroup declares that the group must be passed, either with an identifier or with group-level flags.
+// The name may also be a group alias.
+func (df *DynFlags) RequireGroup(name string) {
+	df.requiredGroups = append(df.requiredGroups, name)
+}
+
+// checkIdentifiers returns an error if the number of parsed identifiers is outside the limits of the group.
+func (cg *ConfigGroup) checkIdentifiers(groupPath string, count int) error {
+	if count < cg.minIdentifiers {
+		return fmt.Errorf("group '%s' requires at least %s, got %d", groupPath, pluralize(cg.minIdentifiers, "identifier"), count)
+	}
+	if cg.maxIdentifiers > 0 && count > cg.maxIdentifiers {
+		return fmt.Errorf("group '%s' allows at most %s, got %d", groupPath, pluralize(cg.maxIdentifiers, "identifier"), count)
+	}
+	return nil
+}
+
+// check returns an error if the parsed group violates the rule.
+func (r rule) check(pg *ParsedGroup) error {
+	var set, missing []string
+	for _, flagName := range r.flags {
+		if _, ok := pg.Values[flagName]; ok {
+			set = append(set, flagName)
+		} else {
+			missing = append(missing, flagName)
+		}
+	}
+
+	switch r.kind {
+	case ruleMutuallyExclusive:
+		if len(set) > 1 {
+			return fmt.Errorf("flags %s are mutually exclusive", quoteList(set, "and"))
+		}
+	case ruleRequiredTogether:
+		if len(set) == 1 && len(missing) > 0 {
+			return fmt.Errorf("flag %s requires %s", quoteList(set, "and"), quoteList(missing, "and"))
+		}
+		if len(set) > 1 && len(missing) > 0 {
+			return fmt.Errorf("flags %s require %s", quoteList(set, "and"), quoteList(missing, "and"))
+		}
+	case ruleOneRequired:
+		if len(set) == 0 && len(r.flags) == 1 {
+			return fmt.Errorf("flag %s is required", quoteList(r.flags, "or"))
+		}
+		if len(set) == 0 {
+			return fmt.Errorf("one of the flags %s is required", quoteList(r.flags, "or"))
+		}
+	}
+	return nil
+}
+
+// AddValidator adds a validator for the whole configuration. Validators run at the end of Parse,
+// after the built-in checks, and their errors are reported as part of the *ValidationError.
+func (df *DynFlags) AddValidator(validate func(*ParsedGroups) error) {
+	df.validators = append(df.validators, validate)
+}
+
+// validate checks the parsed groups against the required groups, the identifier limits,
+// the rules and references of their groups and the custom validators.
+func (df *DynFlags) validate() error {
+	var errs []error
+
+	for _, groupName := range df.requiredGroups {
+		canonical, _ := df.resolveGroup(groupName)
+		if _, ok := df.parsedGroups[canonical]; !ok && df.parsedGlobals[canonical] == nil {
+			errs = append(errs, fmt.Errorf("group '%s' is required", groupName))
+		}
+	}
+
+	for _, groupName := range df.groupOrder {
+		if err := df.configGroups[groupName].checkIdentifiers(groupName, len(df.parsedGroups[groupName])); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	df.walkParsed(func(groupPath, identifier string, pg *ParsedGroup) {
+		for _, r := range pg.Parent.rules {
+			if err := r.check(pg); err != nil {
+				errs = append(errs, fmt.Errorf("%w in %s", err, describeGroup(groupPath, identifier)))
+			}
+		}
+		for _, err := range df.checkRefs(pg) {
+			errs = append(errs, fmt.Errorf("%w in %s", err, describeGroup(groupPath, identifier)))
+		}
+
+		// Limits of child groups apply per identifier
+		if identifier == "" {
+			return
+		}
+		for _, childName := range pg.Parent.groupOrder {
+			childPath := df.keyPattern(groupPath, childName)
+			if err := pg.Parent.groups[childName].checkIdentifiers(childPath, len(pg.groups[childName])); err != nil {
+				errs = append(errs, fmt.Errorf("%w in %s", err, describeGroup(groupPath, identifier)))
+			}
+		}
+	})
+
+	errs = append(errs, df.checkRefCycles()...)
+
+	// Custom validators see the complete configuration
+	if len(df.validators) > 0 {
+		parsed := df.Parsed()
+		for _, validate := range df.validators {
+			if err := validate(parsed); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return &ValidationError{Errors: errs}
+	}
+	return nil
+}
+
+// walkParsed calls fn for every parsed group, including child groups and group-level flags.
+// The group path of child groups includes the identifiers of their parents, e.g. "cluster.eu.node".
+// Groups are visited in the order they were defined, identifiers in alphabetical order.
+// Group-level flags of a group passed only with identifiers are visited without values,
+// so that their rules are checked whenever the group was passed.
+func (df *DynFlags) walkParsed(fn func(groupPath, identifier string, pg *ParsedGroup)) {
+	df.walkParsedGroups("", df.groupOrder, df.configGroups, df.parsedGroups, df.parsedGlobals, fn)
+}
+
+func (df *DynFlags) walkParsedGroups(parentPath string, groupOrder []string, configs map[string]*ConfigGroup, groups GroupsMap, globals map[string]*ParsedGroup, fn func(groupPath, identifier string, pg *ParsedGroup)) {
+	for _, groupName := range groupOrder {
+		groupPath := groupName
+		if parentPath != "" {
+			groupPath = df.keyPattern(parentPath, groupName)
+		}
+
+		global, ok := globals[groupName]
+		if config := configs[groupName]; !ok && len(groups[groupName]) > 0 && config.global != nil {
+			global, ok = &ParsedGroup{Parent: config.global, Name: groupName, Values: make(map[string]any)}, true
+		}
+		if ok {
+			fn(groupPath, "", global)
+		}
+
+		identifiers := make([]string, 0, len(groups[groupName]))
+		for identifier := range groups[groupName] {
+			identifiers = append(identifiers, identifier)
+		}
+		sort.Strings(identifiers)
+
+		for _, identifier := range identifiers {
+			pg := groups[groupName][identifier]
+			fn(groupPath, identifier, pg)
+			df.walkParsedGroups(df.keyPattern(groupPath, identifier), pg.Parent.groupOrder, pg.Parent.groups, pg.groups, pg.globals, fn)
+		}
+	}
+}
+
+// describeGroup describes a parsed group for error messages.
+func describeGroup(groupPath, identifier string) string {
+	if identifier == "" {
+		return fmt.Sprintf("group '%s'", groupPath)
+	}
+	return fmt.Sprintf("group '%s' (identifier '%s')", groupPath, identifier)
+}
+
+// quoteList quotes the names and joins them, e.g. "'a', 'b' and 'c'".
+func quoteList(names []string, conjunction string) string {
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = fmt.Sprintf("'%s'", name)
+	}
+	if len(quoted) == 1 {
+		return quoted[0]
+	}
+	return fmt.Sprintf("%s %s %s", strings.Join(quoted[:len(quoted)-1], ", "), conjunction, quoted[len(quoted)-1])
+}
+
+// pluralize returns the count followed by the noun, adding an "s" if the count is not one.
+func pluralize(count int, noun string) string {
+	if count == 1 {
+		return fmt.Sprintf("%d %s", count, noun)
+	}
+	return fmt.Sprintf("%d %ss", count, noun)
+}
@@ -0,0 +1,365 @@
+package dynflags_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/containeroo/dynflags"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigGroupRules(t *testing.T) {
+	t.Parallel()
+
+	newDynFlags := func() *dynflags.DynFlags {
+		df := dynflags.New(dynflags.ContinueOnError)
+		http := df.Group("http")
+		http.String("body", "", "Request body")
+		http.String("body-file", "", "File with the request body")
+		http.String("client-cert", "", "Client certificate")
+		http.String("client-key", "", "Client key")
+		http.String("address", "", "Target address")
+		http.String("url", "", "Target URL")
+		http.MutuallyExclusive("body", "body-file")
+		http.RequiredTogether("client-cert", "client-key")
+		http.OneRequired("address", "url")
+		return df
+	}
+
+	t.Run("Valid identifiers", func(t *testing.T) {
+		t.Parallel()
+
+		df := newDynFlags()
+		args := []string{
+			"--http.a.address=example.com",
+			"--http.a.body=hello",
+			"--http.b.url=https://example.com",
+			"--http.b.client-cert=cert.pem",
+			"--http.b.client-key=key.pem",
+		}
+		assert.NoError(t, df.Parse(args))
+	})
+
+	t.Run("Mutually exclusive flags", func(t *testing.T) {
+		t.Parallel()
+
+		df := newDynFlags()
+		args := []string{
+			"--http.a.address=example.com",
+			"--http.a.body=hello",
+			"--http.a.body-file=body.json",
+		}
+		err := df.Parse(args)
+		assert.EqualError(t, err, "flags 'body' and 'body-file' are mutually exclusive in group 'http' (identifier 'a')")
+
+		var validationErr *dynflags.ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+		assert.Len(t, validationErr.Errors, 1)
+	})
+
+	t.Run("Required together flags", func(t *testing.T) {
+		t.Parallel()
+
+		df := newDynFlags()
+		args := []string{
+			"--http.a.address=example.com",
+			"--http.a.client-cert=cert.pem",
+		}
+		err := df.Parse(args)
+		assert.EqualError(t, err, "flag 'client-cert' requires 'client-key' in group 'http' (identifier 'a')")
+	})
+
+	t.Run("One required flag", func(t *testing.T) {
+		t.Parallel()
+
+		df := newDynFlags()
+		err := df.Parse([]string{"--http.a.body=hello"})
+		assert.EqualError(t, err, "one of the flags 'address' or 'url' is required in group 'http' (identifier 'a')")
+	})
+
+	t.Run("Single required flag", func(t *testing.T) {
+		t.Parallel()
+
+		df := dynflags.New(dynflags.ContinueOnError)
+		tcp := df.Group("tcp")
+		tcp.String("address", "", "Target address")
+		tcp.String("name", "", "Name")
+		tcp.OneRequired("address")
+
+		err := df.Parse([]string{"--tcp.a.name=db"})
+		assert.EqualError(t, err, "flag 'address' is required in group 'tcp' (identifier 'a')")
+	})
+
+	t.Run("Errors of all identifiers are aggregated", func(t *testing.T) {
+		t.Parallel()
+
+		df := newDynFlags()
+		args := []string{
+			"--http.b.body=hello",
+			"--http.b.body-file=body.json",
+			"--http.a.client-key=key.pem",
+			"--http.a.url=https://example.com",
+		}
+		err := df.Parse(args)
+
+		var validationErr *dynflags.ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+		assert.Len(t, validationErr.Errors, 3)
+		assert.EqualError(t, err, "flag 'client-key' requires 'client-cert' in group 'http' (identifier 'a'); "+
+			"flags 'body' and 'body-file' are mutually exclusive in group 'http' (identifier 'b'); "+
+			"one of the flags 'address' or 'url' is required in group 'http' (identifier 'b')")
+	})
+
+	t.Run("Wildcard values count as set", func(t *testing.T) {
+		t.Parallel()
+
+		df := newDynFlags()
+		args := []string{
+			"--http.*.client-cert=cert.pem",
+			"--http.a.url=https://example.com",
+		}
+		err := df.Parse(args)
+		assert.EqualError(t, err, "flag 'client-cert' requires 'client-key' in group 'http' (identifier 'a')")
+	})
+
+	t.Run("Wildcard values yield to mutually exclusive flags", func(t *testing.T) {
+		t.Parallel()
+
+		df := newDynFlags()
+		args := []string{
+			"--http.*.body=hello",
+			"--http.a.body-file=body.json",
+			"--http.a.url=https://example.com",
+			"--http.b.url=https://example.org",
+		}
+		assert.NoError(t, df.Parse(args))
+
+		http := df.Parsed().Lookup("http")
+		assert.Nil(t, http.Lookup("a").Lookup("body"))
+		assert.Equal(t, "body.json", http.Lookup("a").Lookup("body-file"))
+		assert.Equal(t, "hello", http.Lookup("b").Lookup("body"))
+	})
+
+	t.Run("Rules of child groups and group-level flags", func(t *testing.T) {
+		t.Parallel()
+
+		df := dynflags.New(dynflags.ContinueOnError)
+		cluster := df.Group("cluster")
+		cluster.Global().String("user", "", "User")
+		cluster.Global().String("password", "", "Password")
+		cluster.Global().RequiredTogether("user", "password")
+		node := cluster.Group("node")
+		node.String("address", "", "Node address")
+		node.OneRequired("address")
+
+		args := []string{
+			"--cluster.user=admin",
+			"--cluster.eu.node.n1.address=10.0.0.1",
+			"--cluster.eu.node.n2.address=",
+			"--cluster.us.node.n1.port=80",
+		}
+		err := df.Parse(args)
+		assert.EqualError(t, err, "flag 'user' requires 'password' in group 'cluster'")
+	})
+
+	t.Run("Rules of group-level flags apply whenever the group is passed", func(t *testing.T) {
+		t.Parallel()
+
+		newFlags := func() *dynflags.DynFlags {
+			df := dynflags.New(dynflags.ContinueOnError)
+			cluster := df.Group("cluster")
+			cluster.Global().String("token", "", "Token")
+			cluster.Global().String("token-file", "", "Token file")
+			cluster.Global().OneRequired("token", "token-file")
+			cluster.String("region", "", "Region")
+			cluster.Group("node").Global().String("user", "", "User")
+			cluster.Sub("node").Global().OneRequired("user")
+			cluster.Sub("node").String("address", "", "Node address")
+			return df
+		}
+
+		err := newFlags().Parse([]string{"--cluster.eu.region=eu-west-1", "--cluster.eu.node.n1.address=10.0.0.1"})
+		assert.EqualError(t, err, "one of the flags 'token' or 'token-file' is required in group 'cluster'; "+
+			"flag 'user' is required in group 'cluster.eu.node'")
+
+		assert.NoError(t, newFlags().Parse(nil))
+		assert.NoError(t, newFlags().Parse([]string{"--cluster.token=secret", "--cluster.eu.region=eu-west-1"}))
+	})
+
+	t.Run("Errors of child groups name the parent identifiers", func(t *testing.T) {
+		t.Parallel()
+
+		df := dynflags.New(dynflags.ContinueOnError)
+		node := df.Group("cluster").Group("node")
+		node.String("a", "", "A")
+		node.String("b", "", "B")
+		node.MutuallyExclusive("a", "b")
+
+		args := []string{
+			"--cluster.eu.node.n1.a=1",
+			"--cluster.us.node.n1.a=1",
+			"--cluster.us.node.n1.b=1",
+		}
+		err := df.Parse(args)
+		assert.EqualError(t, err, "flags 'a' and 'b' are mutually exclusive in group 'cluster.us.node' (identifier 'n1')")
+	})
+
+	t.Run("Invalid rules panic", func(t *testing.T) {
+		t.Parallel()
+
+		df := dynflags.New(dynflags.ContinueOnError)
+		http := df.Group("http")
+		http.String("body", "", "Request body")
+		http.String("body-file", "", "File with the request body")
+
+		assert.PanicsWithValue(t, "dynflags: rule refers to unknown flag 'bdy' in group 'http'", func() { http.OneRequired("bdy") })
+		assert.PanicsWithValue(t, "dynflags: rule refers to unknown flag 'bdy' in group 'http'", func() { http.MutuallyExclusive("body-file", "bdy") })
+		assert.PanicsWithValue(t, "dynflags: rule in group 'http' requires at least 2 flags, got 1", func() { http.MutuallyExclusive("body") })
+		assert.PanicsWithValue(t, "dynflags: rule in group 'http' requires at least 2 flags, got 1", func() { http.RequiredTogether("body") })
+		assert.PanicsWithValue(t, "dynflags: rule in group 'http' requires at least 1 flag, got 0", func() { http.OneRequired() })
+		assert.NotPanics(t, func() { http.OneRequired("body") })
+	})
+}
+
+func TestIdentifierLimits(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Minimum identifiers", func(t *testing.T) {
+		t.Parallel()
+
+		df := dynflags.New(dynflags.ContinueOnError)
+		http := df.Group("http")
+		http.String("address", "", "Target address")
+		http.MinIdentifiers(1)
+
+		err := df.Parse(nil)
+		assert.EqualError(t, err, "group 'http' requires at least 1 identifier, got 0")
+
+		df = dynflags.New(dynflags.ContinueOnError)
+		df.Group("http").String("address", "", "Target address")
+		df.Group("http").MinIdentifiers(1)
+		assert.NoError(t, df.Parse([]string{"--http.a.address=example.com"}))
+	})
+
+	t.Run("Maximum identifiers", func(t *testing.T) {
+		t.Parallel()
+
+		df := dynflags.New(dynflags.ContinueOnError)
+		db := df.Group("db")
+		db.String("dsn", "", "Database DSN")
+		db.MaxIdentifiers(1)
+
+		err := df.Parse([]string{"--db.a.dsn=a", "--db.b.dsn=b"})
+		assert.EqualError(t, err, "group 'db' allows at most 1 identifier, got 2")
+
+		var validationErr *dynflags.ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+	})
+
+	t.Run("Wildcard identifier is not counted", func(t *testing.T) {
+		t.Parallel()
+
+		df := dynflags.New(dynflags.ContinueOnError)
+		db := df.Group("db")
+		db.String("dsn", "", "Database DSN")
+		db.MaxIdentifiers(1)
+
+		assert.NoError(t, df.Parse([]string{"--db.*.dsn=a", "--db.b.dsn=b"}))
+	})
+
+	t.Run("Limits of child groups apply per identifier", func(t *testing.T) {
+		t.Parallel()
+
+		df := dynflags.New(dynflags.ContinueOnError)
+		cluster := df.Group("cluster")
+		cluster.String("region", "", "Region")
+		node := cluster.Group("node")
+		node.String("address", "", "Node address")
+		node.MinIdentifiers(2)
+
+		args := []string{
+			"--cluster.eu.node.n1.address=10.0.0.1",
+			"--cluster.eu.node.n2.address=10.0.0.2",
+			"--cluster.us.region=us-east-1",
+		}
+		err := df.Parse(args)
+		assert.EqualError(t, err, "group 'cluster.node' requires at least 2 identifiers, got 0 in group 'cluster' (identifier 'us')")
+	})
+
+	t.Run("Invalid limits panic", func(t *testing.T) {
+		t.Parallel()
+
+		group := &dynflags.ConfigGroup{Name: "http", Flags: make(map[string]*dynflags.Flag)}
+		assert.Panics(t, func() { group.MinIdentifiers(-1) })
+		assert.Panics(t, func() { group.MaxIdentifiers(0) })
+	})
+}
+
+func TestRequireGroup(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Missing required group", func(t *testing.T) {
+		t.Parallel()
+
+		df := dynflags.New(dynflags.ContinueOnError)
+		df.Group("db").String("dsn", "", "Database DSN")
+		df.Group("http").String("address", "", "Target address")
+		df.RequireGroup("db")
+		df.Group("http").MinIdentifiers(1)
+
+		err := df.Parse(nil)
+		assert.EqualError(t, err, "group 'db' is required; group 'http' requires at least 1 identifier, got 0")
+
+		var validationErr *dynflags.ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+		assert.Len(t, validationErr.Errors, 2)
+	})
+
+	t.Run("Group-level flags satisfy a required group", func(t *testing.T) {
+		t.Parallel()
+
+		df := dynflags.New(dynflags.ContinueOnError)
+		df.Group("db").Global().String("dsn", "", "Database DSN")
+		df.RequireGroup("db")
+
+		assert.NoError(t, df.Parse([]string{"--db.dsn=postgres://"}))
+	})
+}
+
+func TestAddValidator(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Validator sees the parsed configuration", func(t *testing.T) {
+		t.Parallel()
+
+		df := dynflags.New(dynflags.ContinueOnError)
+		df.Group("http").String("address", "", "Target address")
+
+		var seen *dynflags.ParsedGroups
+		df.AddValidator(func(parsed *dynflags.ParsedGroups) error {
+			seen = parsed
+			return nil
+		})
+
+		assert.NoError(t, df.Parse([]string{"--http.a.address=example.com"}))
+		assert.Equal(t, "example.com", seen.Lookup("http").Lookup("a").Lookup("address"))
+	})
+
+	t.Run("Validator errors are aggregated", func(t *testing.T) {
+		t.Parallel()
+
+		df := dynflags.New(dynflags.ContinueOnError)
+		http := df.Group("http")
+		http.String("address", "", "Target address")
+		http.MinIdentifiers(2)
+
+		errFirst := errors.New("first")
+		df.AddValidator(func(*dynflags.ParsedGroups) error { return errFirst })
+		df.AddValidator(func(*dynflags.ParsedGroups) error { return nil })
+		df.AddValidator(func(*dynflags.ParsedGroups) error { return errors.New("third") })
+
+		err := df.Parse([]string{"--http.a.address=example.com"})
+		assert.EqualError(t, err, "group 'http' requires at least 2 identifiers, got 1; first; third")
+		assert.ErrorIs(t, err, errFirst)
+	})
+}
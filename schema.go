@@ -0,0 +1,267 @@
+package dynflags
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// schemaDraft is the JSON Schema dialect of the generated schema.
+const schemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
+// durationPattern matches values accepted by time.ParseDuration.
+const durationPattern = `^[-+]?(0|([0-9]*(\.[0-9]*)?(ns|us|µs|μs|ms|s|m|h))+)$`
+
+// schemaItemTypes maps the slice flag types to the type of their items.
+var schemaItemTypes = map[FlagType]FlagType{
+	FlagTypeStringSlice:   FlagTypeString,
+	FlagTypeIntSlice:      FlagTypeInt,
+	FlagTypeBoolSlice:     FlagTypeBool,
+	FlagTypeDurationSlice: FlagTypeDuration,
+	FlagTypeFloatSlice:    FlagTypeFloat,
+	FlagTypeIPSlice:       FlagTypeIP,
+	FlagTypeURLSlice:      FlagTypeURL,
+}
+
+// JSONSchema returns a JSON Schema (draft 2020-12) describing a configuration file with the
+// same structure as the flags: every group is an object keyed by identifier, and every
+// identifier is an object holding the flags and child groups of the group. Group-level flags
+// are properties of the group object next to the identifiers.
+// The schema includes types, defaults, numeric ranges, deprecations and the rules of the groups.
+func (df *DynFlags) JSONSchema() ([]byte, error) {
+	properties := make(map[string]any, len(df.configGroups))
+	for _, groupName := range df.groupOrder {
+		properties[groupName] = groupSchema(df.configGroups[groupName])
+	}
+
+	schema := map[string]any{
+		"$schema":              schemaDraft,
+		"type":                 "object",
+		"properties":           properties,
+		"additionalProperties": false,
+	}
+	if df.title != "" {
+		schema["title"] = df.title
+	}
+	if df.description != "" {
+		schema["description"] = df.description
+	}
+	if len(df.requiredGroups) > 0 {
+		required := make([]string, 0, len(df.requiredGroups))
+		for _, groupName := range df.requiredGroups {
+			canonical, _ := df.resolveGroup(groupName)
+			required = append(required, canonical)
+		}
+		schema["required"] = required
+	}
+	return json.MarshalIndent(schema, "", "  ")
+}
+
+// groupSchema describes a group as an object keyed by identifier.
+func groupSchema(group *ConfigGroup) map[string]any {
+	schema := map[string]any{
+		"type":                 "object",
+		"additionalProperties": identifierSchema(group),
+	}
+	if group.usage != "" {
+		schema["description"] = group.usage
+	}
+
+	// Group-level flags share the object with the identifiers, so the
+	// identifier limits can only be expressed without them
+	if group.global != nil && len(group.global.Flags) > 0 {
+		schema["properties"] = flagProperties(group.global)
+		addRules(schema, group.global.rules)
+	} else {
+		if group.minIdentifiers > 0 {
+			schema["minProperties"] = group.minIdentifiers
+		}
+		if group.maxIdentifiers > 0 {
+			schema["maxProperties"] = group.maxIdentifiers
+		}
+	}
+	return schema
+}
+
+// identifierSchema describes an identifier of a group with its flags and child groups.
+func identifierSchema(group *ConfigGroup) map[string]any {
+	properties := flagProperties(group)
+	for _, childName := range group.groupOrder {
+		properties[childName] = groupSchema(group.groups[childName])
+	}
+
+	schema := map[string]any{
+		"type":                 "object",
+		"properties":           properties,
+		"additionalProperties": false,
+	}
+	addRules(schema, group.rules)
+	return schema
+}
+
+// flagProperties describes the flags of a group.
+func flagProperties(group *ConfigGroup) map[string]any {
+	properties := make(map[string]any, len(group.Flags))
+	for _, flagName := range group.flagOrder {
+		properties[flagName] = flagSchema(group.Flags[flagName])
+	}
+	return properties
+}
+
+// flagSchema describes the value of a flag.
+func flagSchema(flag *Flag) map[string]any {
+	var schema, values map[string]any
+	if itemType, ok := schemaItemTypes[flag.Type]; ok {
+		values = typeSchema(itemType)
+		schema = map[string]any{"type": "array", "items": values}
+	} else {
+		values = typeSchema(flag.Type)
+		schema = values
+	}
+	// Bounds apply to each value; duration bounds cannot be expressed by the schema
+	switch flag.min.(type) {
+	case int, float64:
+		values["minimum"] = flag.min
+	}
+	switch flag.max.(type) {
+	case int, float64:
+		values["maximum"] = flag.max
+	}
+
+	if flag.Usage != "" {
+		schema["description"] = flag.Usage
+	}
+	if value, ok := schemaDefault(flag); ok {
+		schema["default"] = value
+	}
+	if flag.deprecated != "" {
+		schema["deprecated"] = true
+	}
+	return schema
+}
+
+// typeSchema describes a single value of the given flag type.
+func typeSchema(flagType FlagType) map[string]any {
+	switch flagType {
+	case FlagTypeInt:
+		return map[string]any{"type": "integer"}
+	case FlagTypeFloat:
+		return map[string]any{"type": "number"}
+	case FlagTypeBool:
+		return map[string]any{"type": "boolean"}
+	case FlagTypeDuration:
+		return map[string]any{"type": "string", "pattern": durationPattern}
+	case FlagTypeIP:
+		return map[string]any{"type": "string", "anyOf": []any{
+			map[string]any{"format": "ipv4"},
+			map[string]any{"format": "ipv6"},
+		}}
+	case FlagTypeURL:
+		return map[string]any{"type": "string", "format": "uri"}
+	default:
+		return map[string]any{"type": "string"}
+	}
+}
+
+// schemaDefault returns the default value of a flag as it is written in a configuration file.
+func schemaDefault(flag *Flag) (any, bool) {
+	return jsonValue(flag.Type, flag.Default)
+}
+
+// jsonValue returns a value of a flag of the given type as it is written in JSON; durations are
+// written as strings and comma-separated slice values as arrays. It reports false for empty values.
+func jsonValue(flagType FlagType, value any) (any, bool) {
+	switch value := value.(type) {
+	case nil:
+		return nil, false
+	case time.Duration:
+		return value.String(), true
+	case string:
+		if value == "" {
+			return nil, false
+		}
+		itemType, ok := schemaItemTypes[flagType]
+		if !ok {
+			return value, true
+		}
+		var items []any
+		for _, item := range strings.Split(value, ",") {
+			items = append(items, schemaValue(itemType, item))
+		}
+		return items, true
+	default:
+		return value, true
+	}
+}
+
+// schemaValue converts a value given as string to the JSON type of the flag type.
+func schemaValue(flagType FlagType, value string) any {
+	switch flagType {
+	case FlagTypeInt:
+		if v, err := strconv.Atoi(value); err == nil {
+			return v
+		}
+	case FlagTypeFloat:
+		if v, err := strconv.ParseFloat(value, 64); err == nil {
+			return v
+		}
+	case FlagTypeBool:
+		if v, err := strconv.ParseBool(value); err == nil {
+			return v
+		}
+	}
+	return value
+}
+
+// addRules expresses the rules of a group in the schema of its identifiers.
+func addRules(schema map[string]any, rules []rule) {
+	var required []string
+	var allOf []any
+	dependentRequired := make(map[string][]string)
+
+	for _, r := range rules {
+		switch r.kind {
+		case ruleOneRequired:
+			if len(r.flags) == 1 {
+				required = append(required, r.flags[0])
+				continue
+			}
+			allOf = append(allOf, map[string]any{"anyOf": requiredEach(r.flags)})
+		case ruleRequiredTogether:
+			for i, flagName := range r.flags {
+				others := append(append([]string{}, r.flags[:i]...), r.flags[i+1:]...)
+				dependentRequired[flagName] = append(dependentRequired[flagName], others...)
+			}
+		case ruleMutuallyExclusive:
+			var pairs []any
+			for i := range r.flags {
+				for _, other := range r.flags[i+1:] {
+					pairs = append(pairs, map[string]any{"required": []string{r.flags[i], other}})
+				}
+			}
+			if len(pairs) > 0 {
+				allOf = append(allOf, map[string]any{"not": map[string]any{"anyOf": pairs}})
+			}
+		}
+	}
+
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	if len(dependentRequired) > 0 {
+		schema["dependentRequired"] = dependentRequired
+	}
+	if len(allOf) > 0 {
+		schema["allOf"] = allOf
+	}
+}
+
+// requiredEach returns one "required" constraint per flag.
+func requiredEach(flagNames []string) []any {
+	constraints := make([]any, 0, len(flagNames))
+	for _, flagName := range flagNames {
+		constraints = append(constraints, map[string]any{"required": []string{flagName}})
+	}
+	return constraints
+}
@@ -0,0 +1,130 @@
+package dynflags_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/containeroo/dynflags"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJSONSchema(t *testing.T) {
+	t.Parallel()
+
+	// schemaOf returns the schema of df decoded into generic values.
+	schemaOf := func(t *testing.T, df *dynflags.DynFlags) map[string]any {
+		t.Helper()
+
+		data, err := df.JSONSchema()
+		if !assert.NoError(t, err) {
+			t.FailNow()
+		}
+
+		var schema map[string]any
+		if !assert.NoError(t, json.Unmarshal(data, &schema)) {
+			t.FailNow()
+		}
+		return schema
+	}
+
+	t.Run("Groups are objects keyed by identifier", func(t *testing.T) {
+		t.Parallel()
+
+		df := dynflags.New(dynflags.ContinueOnError)
+		df.Title("Checker")
+		http := df.Group("http")
+		http.Usage("HTTP flags")
+		http.String("method", "GET", "HTTP method")
+		http.Int("retries", 3, "Retries").Range(0, 10)
+		http.Duration("timeout", 2*time.Second, "Timeout").Range(time.Second, time.Minute)
+		http.Bool("insecure", false, "Skip TLS verification").Deprecated("use tls.verify")
+		http.StringSlices("header", []string{"a=b", "c=d"}, "Headers")
+		http.IntSlices("codes", []int{200, 204}, "Status codes").Range(100, 599)
+		http.Float64Slices("weights", nil, "Weights").Min(0)
+		http.URL("url", "", "Target URL")
+		df.RequireGroup("http")
+
+		schema := schemaOf(t, df)
+		assert.Equal(t, "https://json-schema.org/draft/2020-12/schema", schema["$schema"])
+		assert.Equal(t, "Checker", schema["title"])
+		assert.Equal(t, false, schema["additionalProperties"])
+		assert.Equal(t, []any{"http"}, schema["required"])
+
+		group := schema["properties"].(map[string]any)["http"].(map[string]any)
+		assert.Equal(t, "object", group["type"])
+		assert.Equal(t, "HTTP flags", group["description"])
+
+		identifier := group["additionalProperties"].(map[string]any)
+		assert.Equal(t, false, identifier["additionalProperties"])
+		flags := identifier["properties"].(map[string]any)
+
+		assert.Equal(t, map[string]any{"type": "string", "description": "HTTP method", "default": "GET"}, flags["method"])
+		assert.Equal(t, map[string]any{"type": "integer", "description": "Retries", "default": 3.0, "minimum": 0.0, "maximum": 10.0}, flags["retries"])
+		assert.Equal(t, "2s", flags["timeout"].(map[string]any)["default"])
+		assert.NotContains(t, flags["timeout"], "minimum")
+		assert.Equal(t, true, flags["insecure"].(map[string]any)["deprecated"])
+		assert.Equal(t, map[string]any{
+			"type":        "array",
+			"items":       map[string]any{"type": "string"},
+			"description": "Headers",
+			"default":     []any{"a=b", "c=d"},
+		}, flags["header"])
+		assert.Equal(t, map[string]any{
+			"type":        "array",
+			"items":       map[string]any{"type": "integer", "minimum": 100.0, "maximum": 599.0},
+			"description": "Status codes",
+			"default":     []any{200.0, 204.0},
+		}, flags["codes"])
+		assert.Equal(t, map[string]any{"type": "number", "minimum": 0.0}, flags["weights"].(map[string]any)["items"])
+		assert.Equal(t, map[string]any{"type": "string", "format": "uri", "description": "Target URL"}, flags["url"])
+	})
+
+	t.Run("Group-level flags, child groups and limits", func(t *testing.T) {
+		t.Parallel()
+
+		df := dynflags.New(dynflags.ContinueOnError)
+		http := df.Group("http")
+		http.Global().Int("concurrency", 1, "Concurrency")
+		http.MaxIdentifiers(3)
+		cluster := df.Group("cluster")
+		cluster.MinIdentifiers(1)
+		cluster.Group("node").String("address", "", "Node address")
+
+		schema := schemaOf(t, df)
+		groups := schema["properties"].(map[string]any)
+
+		httpSchema := groups["http"].(map[string]any)
+		assert.Contains(t, httpSchema["properties"], "concurrency")
+		assert.NotContains(t, httpSchema, "maxProperties")
+
+		clusterSchema := groups["cluster"].(map[string]any)
+		assert.Equal(t, 1.0, clusterSchema["minProperties"])
+		node := clusterSchema["additionalProperties"].(map[string]any)["properties"].(map[string]any)["node"].(map[string]any)
+		assert.Contains(t, node["additionalProperties"].(map[string]any)["properties"], "address")
+	})
+
+	t.Run("Rules", func(t *testing.T) {
+		t.Parallel()
+
+		df := dynflags.New(dynflags.ContinueOnError)
+		http := df.Group("http")
+		for _, name := range []string{"address", "user", "password", "token", "cert", "key"} {
+			http.String(name, "", name)
+		}
+		http.OneRequired("address")
+		http.RequiredTogether("user", "password")
+		http.MutuallyExclusive("token", "password")
+		http.OneRequired("cert", "key")
+
+		schema := schemaOf(t, df)
+		identifier := schema["properties"].(map[string]any)["http"].(map[string]any)["additionalProperties"].(map[string]any)
+
+		assert.Equal(t, []any{"address"}, identifier["required"])
+		assert.Equal(t, map[string]any{"user": []any{"password"}, "password": []any{"user"}}, identifier["dependentRequired"])
+		assert.Equal(t, []any{
+			map[string]any{"not": map[string]any{"anyOf": []any{map[string]any{"required": []any{"token", "password"}}}}},
+			map[string]any{"anyOf": []any{map[string]any{"required": []any{"cert"}}, map[string]any{"required": []any{"key"}}}},
+		}, identifier["allOf"])
+	})
+}
@@ -35,8 +35,7 @@ func (g *ConfigGroup) String(name, value, usage string) *Flag {
 		Usage:   usage,
 		value:   &StringValue{Bound: bound},
 	}
-	g.Flags[name] = flag
-	g.flagOrder = append(g.flagOrder, name)
+	g.addFlag(name, flag)
 	return flag
 }
 
@@ -38,8 +38,7 @@ func (g *ConfigGroup) StringSlices(name string, value []string, usage string) *F
 		Usage:   usage,
 		value:   &StringSlicesValue{Bound: bound},
 	}
-	g.Flags[name] = flag
-	g.flagOrder = append(g.flagOrder, name)
+	g.addFlag(name, flag)
 	return flag
 }
 
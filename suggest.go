@@ -0,0 +1,77 @@
+package dynflags
+
+import "unicode/utf8"
+
+// suggest returns the candidate most similar to name, or an empty string if none is similar enough.
+func suggest(name string, candidates []string) string {
+	length := utf8.RuneCountInString(name)
+	maxDistance := max(1, length/3)
+
+	best, bestDistance := "", maxDistance+1
+	for _, candidate := range candidates {
+		if d := levenshtein(name, candidate); d < bestDistance && d < length {
+			best, bestDistance = candidate, d
+		}
+	}
+	return best
+}
+
+// levenshtein returns the edit distance between a and b.
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
+
+// groupNames returns the names of all groups shown in the help message.
+func (df *DynFlags) groupNames() []string {
+	var names []string
+	for _, name := range df.groupOrder {
+		if !df.configGroups[name].hidden {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// groupNames returns the names of all child groups shown in the help message.
+func (cg *ConfigGroup) groupNames() []string {
+	var names []string
+	for _, name := range cg.groupOrder {
+		if !cg.groups[name].hidden {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// flagNames returns the names and aliases of all flags shown in the help message.
+func (cg *ConfigGroup) flagNames() []string {
+	if cg == nil {
+		return nil
+	}
+	var names []string
+	for _, name := range cg.flagOrder {
+		if flag := cg.Flags[name]; flag.shown(false) {
+			names = append(names, name)
+			names = append(names, flag.aliases...)
+		}
+	}
+	return names
+}
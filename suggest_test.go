@@ -0,0 +1,89 @@
+package dynflags_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/containeroo/dynflags"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSuggestions(t *testing.T) {
+	t.Parallel()
+
+	newFlags := func() *dynflags.DynFlags {
+		df := newExitOnError()
+		http := df.Group("http")
+		http.Duration("timeout", 0, "Timeout")
+		http.String("method", "GET", "Method").Alias("verb")
+		http.String("secret", "", "Secret").Hidden()
+		http.Global().Int("concurrency", 1, "Concurrency")
+		node := df.Group("cluster").Group("node")
+		node.String("address", "", "Address")
+		df.Group("internal").Hidden()
+		return df
+	}
+
+	t.Run("Suggest similar flag", func(t *testing.T) {
+		t.Parallel()
+
+		df := newFlags()
+		err := df.Parse([]string{"--http.a.timout", "1s"})
+		assert.EqualError(t, err, "unknown flag 'timout' in group 'http'; did you mean 'timeout'?")
+
+		var unknown *dynflags.UnknownFlagError
+		assert.True(t, errors.As(err, &unknown))
+		assert.Equal(t, "timeout", unknown.Suggestion)
+	})
+
+	t.Run("Suggest similar alias", func(t *testing.T) {
+		t.Parallel()
+
+		df := newFlags()
+		err := df.Parse([]string{"--http.a.verv", "POST"})
+		assert.EqualError(t, err, "unknown flag 'verv' in group 'http'; did you mean 'verb'?")
+	})
+
+	t.Run("Suggest similar group-level flag", func(t *testing.T) {
+		t.Parallel()
+
+		df := newFlags()
+		err := df.Parse([]string{"--http.concurency", "2"})
+		assert.EqualError(t, err, "unknown flag 'concurency' in group 'http'; did you mean 'concurrency'?")
+	})
+
+	t.Run("Suggest similar group", func(t *testing.T) {
+		t.Parallel()
+
+		df := newFlags()
+		err := df.Parse([]string{"--htp.a.timeout", "1s"})
+		assert.EqualError(t, err, "unknown flag 'timeout' in group 'htp'; did you mean group 'http'?")
+	})
+
+	t.Run("Suggest similar child group", func(t *testing.T) {
+		t.Parallel()
+
+		df := newFlags()
+		err := df.Parse([]string{"--cluster.a.nod.b.address", "x"})
+		assert.EqualError(t, err, "unknown flag 'address' in group 'cluster.a.nod'; did you mean group 'cluster.a.node'?")
+	})
+
+	t.Run("Hidden flags and groups are not suggested", func(t *testing.T) {
+		t.Parallel()
+
+		df := newFlags()
+		err := df.Parse([]string{"--http.a.secrt", "x"})
+		assert.EqualError(t, err, "unknown flag 'secrt' in group 'http'")
+
+		err = df.Parse([]string{"--intern.a.flag", "x"})
+		assert.EqualError(t, err, "unknown flag 'flag' in group 'intern'")
+	})
+
+	t.Run("No suggestion for dissimilar names", func(t *testing.T) {
+		t.Parallel()
+
+		df := newFlags()
+		err := df.Parse([]string{"--http.a.x", "1"})
+		assert.EqualError(t, err, "unknown flag 'x' in group 'http'")
+	})
+}
@@ -0,0 +1,10 @@
+//go:build !(linux || darwin || freebsd || netbsd || openbsd || dragonfly)
+
+package dynflags
+
+import "os"
+
+// terminalWidth returns zero, as the terminal size cannot be detected on this platform.
+func terminalWidth(f *os.File) int {
+	return 0
+}
@@ -45,8 +45,7 @@ func (g *ConfigGroup) URL(name, value, usage string) *Flag {
 		Usage:   usage,
 		value:   &URLValue{Bound: bound},
 	}
-	g.Flags[name] = flag
-	g.flagOrder = append(g.flagOrder, name)
+	g.addFlag(name, flag)
 	return flag
 }
 
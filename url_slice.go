@@ -48,8 +48,7 @@ func (g *ConfigGroup) URLSlices(name string, value []*url.URL, usage string) *Fl
 		Usage:   usage,
 		value:   &URLSlicesValue{Bound: bound},
 	}
-	g.Flags[name] = flag
-	g.flagOrder = append(g.flagOrder, name)
+	g.addFlag(name, flag)
 	return flag
 }
 
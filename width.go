@@ -0,0 +1,54 @@
+package dynflags
+
+import (
+	"io"
+	"os"
+	"strings"
+	"unicode/utf8"
+)
+
+// minUsageWidth is the narrowest column usage text is wrapped to.
+const minUsageWidth = 20
+
+// SetWidth sets the line width the usage text in the help message is wrapped to.
+// By default (zero), the width of the terminal is used if the output is one; other output is not wrapped.
+// A negative width disables wrapping.
+func (df *DynFlags) SetWidth(width int) {
+	df.width = width
+}
+
+// helpWidth returns the line width for help written to w; zero disables wrapping.
+func (df *DynFlags) helpWidth(w io.Writer) int {
+	switch {
+	case df.width > 0:
+		return df.width
+	case df.width < 0:
+		return 0
+	}
+	if f, ok := w.(*os.File); ok {
+		return terminalWidth(f)
+	}
+	return 0
+}
+
+// wrapText splits text into lines of at most width characters at word boundaries.
+// Words longer than width are kept on a line of their own. A width of zero disables wrapping.
+func wrapText(text string, width int) []string {
+	if width <= 0 || utf8.RuneCountInString(text) <= width {
+		return []string{text}
+	}
+
+	var lines []string
+	var line strings.Builder
+	for _, word := range strings.Fields(text) {
+		if line.Len() > 0 && utf8.RuneCountInString(line.String())+1+utf8.RuneCountInString(word) > width {
+			lines = append(lines, line.String())
+			line.Reset()
+		}
+		if line.Len() > 0 {
+			line.WriteByte(' ')
+		}
+		line.WriteString(word)
+	}
+	return append(lines, line.String())
+}
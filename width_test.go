@@ -0,0 +1,91 @@
+package dynflags_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/containeroo/dynflags"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrintDefaultsWidth(t *testing.T) {
+	t.Parallel()
+
+	const usage = "Address of the target, including the port, used for all requests of the checker"
+
+	newFlags := func() (*dynflags.DynFlags, *bytes.Buffer) {
+		var buf bytes.Buffer
+		df := dynflags.New(dynflags.ContinueOnError)
+		df.SetOutput(&buf)
+		http := df.Group("http")
+		http.String("address", "", usage)
+		http.String("method", "GET", "HTTP method")
+		return df, &buf
+	}
+
+	t.Run("Output that is not a terminal is not wrapped", func(t *testing.T) {
+		t.Parallel()
+
+		df, buf := newFlags()
+		df.PrintDefaults()
+		assert.Contains(t, buf.String(), usage)
+	})
+
+	t.Run("Usage is wrapped to the configured width", func(t *testing.T) {
+		t.Parallel()
+
+		df, buf := newFlags()
+		df.SetWidth(80)
+		df.PrintDefaults()
+
+		expected := "HTTP\n" +
+			"  Flag                                Usage\n" +
+			"  --http.<IDENTIFIER>.address STRING  Address of the target, including the port,\n" +
+			"                                      used for all requests of the checker\n" +
+			"  --http.<IDENTIFIER>.method STRING   HTTP method (default: GET)\n" +
+			"\n"
+		assert.Equal(t, expected, buf.String())
+		for _, line := range strings.Split(buf.String(), "\n") {
+			assert.LessOrEqual(t, len(line), 80)
+		}
+	})
+
+	t.Run("Group-level flags are wrapped to their own column", func(t *testing.T) {
+		t.Parallel()
+
+		df, buf := newFlags()
+		df.Group("http").Global().Int("concurrency", 1, "Number of checks running in parallel across all identifiers")
+		df.SetWidth(60)
+		df.PrintDefaults()
+
+		expected := "HTTP\n" +
+			"  Flag                    Usage\n" +
+			"  --http.concurrency INT  Number of checks running in\n" +
+			"                          parallel across all identifiers\n" +
+			"                          (default: 1)\n" +
+			"\n" +
+			"  --http.<IDENTIFIER>.address STRING  Address of the target,\n" +
+			"                                      including the port,\n"
+		assert.True(t, strings.HasPrefix(buf.String(), expected), buf.String())
+	})
+
+	t.Run("Usage keeps a minimum width", func(t *testing.T) {
+		t.Parallel()
+
+		df, buf := newFlags()
+		df.SetWidth(10)
+		df.PrintDefaults()
+		assert.Contains(t, buf.String(), "  --http.<IDENTIFIER>.address STRING  Address of the\n"+
+			"                                      target, including\n")
+	})
+
+	t.Run("Negative width disables wrapping", func(t *testing.T) {
+		t.Parallel()
+
+		df, buf := newFlags()
+		df.SetWidth(-1)
+		df.PrintDefaults()
+		assert.Contains(t, buf.String(), usage)
+	})
+}